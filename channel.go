@@ -0,0 +1,127 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Channels is an ordered list of prerelease channels, from the least to the most stable one. Version is on
+// a channel if its first prerelease identifier is the channel name; identifier following it is a counter:
+// `1.0.0-beta.2` is second prerelease on `beta` channel. Channel names are compared as prerelease identifiers, so
+// moving between channels listed in other order than ascending one, like `snapshot` and `rc`, would lower the
+// version; such move fails with ErrChannelBackwards.
+type Channels []string
+
+// DefaultChannels are channels used by NextChannel and ToChannel: `alpha`, `beta` and `rc`
+var DefaultChannels = Channels{"alpha", "beta", "rc"}
+
+// ErrUnknownChannel is returned if channel is not on the list of channels
+var ErrUnknownChannel = errors.New("unknown prerelease channel")
+
+// ErrChannelBackwards is returned on attempt to move to less stable channel than the current one or to move
+// in a way that lowers precedence of the version
+var ErrChannelBackwards = errors.New("can not move back to less stable prerelease channel")
+
+// NextChannel is DefaultChannels.Next
+func NextChannel() BumpOption {
+	return DefaultChannels.Next()
+}
+
+// ToChannel is DefaultChannels.To
+func ToChannel(name string) BumpOption {
+	return DefaultChannels.To(name)
+}
+
+// EnterChannel is DefaultChannels.Enter
+func EnterChannel(name string) BumpOption {
+	return DefaultChannels.Enter(name)
+}
+
+// Next returns option to move version to the next channel with counter reset to 1: `1.0.0-alpha.3` ->
+// `1.0.0-beta.1`. Version on the last channel is moved to release: `1.0.0-rc.2` -> `1.0.0`. ErrNoPrerelease
+// is returned if version is not a prerelease and ErrUnknownChannel if it is not on any of the channels.
+func (c Channels) Next() BumpOption {
+	return func(v *Version) error {
+		if len(v.Prerelease) == 0 {
+			return ErrNoPrerelease
+		}
+		current, err := c.index(v.Prerelease[0])
+		if err != nil {
+			return err
+		}
+		if current == len(c)-1 {
+			v.Prerelease = []string{}
+			return nil
+		}
+		return moveForward(v, []string{c[current+1], "1"})
+	}
+}
+
+// To returns option to move version to the named channel with counter reset to 1: `1.0.0-alpha.3` ->
+// `1.0.0-rc.1`. If version is already on that channel its counter is incremented: `1.0.0-rc.1` -> `1.0.0-rc.2`.
+// Moving to less stable channel fails with ErrChannelBackwards and ErrNoPrerelease is returned if version is not
+// a prerelease as any channel is lower than release; use Enter to start a channel.
+func (c Channels) To(name string) BumpOption {
+	return func(v *Version) error {
+		target, err := c.index(name)
+		if err != nil {
+			return err
+		}
+		if len(v.Prerelease) == 0 {
+			return ErrNoPrerelease
+		}
+		current, err := c.index(v.Prerelease[0])
+		if err != nil {
+			return err
+		}
+		if target < current {
+			return fmt.Errorf("%w: %s -> %s", ErrChannelBackwards, v.Prerelease[0], name)
+		}
+		if target > current || len(v.Prerelease) == 1 || !isNum(v.Prerelease[1]) {
+			// `rc.1` is lower than `rc.final` so the latter is refused here
+			return moveForward(v, []string{name, "1"})
+		}
+		counter, err := increment(v.Prerelease[1])
+		if err != nil {
+			return err
+		}
+		return moveForward(v, []string{name, counter})
+	}
+}
+
+// moveForward sets prerelease of the version unless it lowers its precedence
+func moveForward(v *Version, prerelease []string) error {
+	moved := *v
+	moved.Prerelease = prerelease
+	if !Less(v, &moved) {
+		return fmt.Errorf("%w: %s -> %s", ErrChannelBackwards, strings.Join(v.Prerelease, "."), strings.Join(prerelease, "."))
+	}
+	v.Prerelease = prerelease
+	return nil
+}
+
+// Enter returns option to start the named channel on release version: `1.1.0` -> `1.1.0-rc.1`. Result is lower
+// than the release so Enter is meant to follow one of core number bumps: `Bump(NextMinor(), EnterChannel("rc"))`.
+// Prerelease version is moved the same way To does it.
+func (c Channels) Enter(name string) BumpOption {
+	return func(v *Version) error {
+		if len(v.Prerelease) > 0 {
+			return c.To(name)(v)
+		}
+		if _, err := c.index(name); err != nil {
+			return err
+		}
+		v.Prerelease = []string{name, "1"}
+		return nil
+	}
+}
+
+func (c Channels) index(name string) (int, error) {
+	for i, channel := range c {
+		if channel == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %s", ErrUnknownChannel, name)
+}
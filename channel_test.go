@@ -0,0 +1,77 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestChannels(t *testing.T) {
+	tests := []struct {
+		version string
+		options []semver.BumpOption
+		want    string
+		err     error
+	}{
+		{"1.0.0-alpha.3", []semver.BumpOption{semver.NextChannel()}, "1.0.0-beta.1", nil},
+		{"1.0.0-beta", []semver.BumpOption{semver.NextChannel()}, "1.0.0-rc.1", nil},
+		{"1.0.0-rc.2", []semver.BumpOption{semver.NextChannel()}, "1.0.0", nil},
+		{"1.0.0", []semver.BumpOption{semver.NextChannel()}, "", semver.ErrNoPrerelease},
+		{"1.0.0-dev.1", []semver.BumpOption{semver.NextChannel()}, "", semver.ErrUnknownChannel},
+		{"1.0.0-alpha.3", []semver.BumpOption{semver.ToChannel("rc")}, "1.0.0-rc.1", nil},
+		{"1.0.0-alpha.3", []semver.BumpOption{semver.ToChannel("alpha")}, "1.0.0-alpha.4", nil},
+		{"1.0.0-alpha", []semver.BumpOption{semver.ToChannel("alpha")}, "1.0.0-alpha.1", nil},
+		{"1.0.0-alpha.3.x", []semver.BumpOption{semver.ToChannel("alpha")}, "1.0.0-alpha.4", nil},
+		{"1.0.0-alpha.x", []semver.BumpOption{semver.ToChannel("alpha")}, "", semver.ErrChannelBackwards},
+		{"1.0.0-rc.1", []semver.BumpOption{semver.ToChannel("beta")}, "", semver.ErrChannelBackwards},
+		{"1.0.0-rc.1", []semver.BumpOption{semver.ToChannel("gamma")}, "", semver.ErrUnknownChannel},
+		{"1.1.0", []semver.BumpOption{semver.ToChannel("rc")}, "", semver.ErrNoPrerelease},
+		{"1.0.0", []semver.BumpOption{semver.NextMinor(), semver.ToChannel("alpha")}, "", semver.ErrNoPrerelease},
+		{"1.0.0", []semver.BumpOption{semver.NextMinor(), semver.EnterChannel("alpha")}, "1.1.0-alpha.1", nil},
+		{"1.0.0", []semver.BumpOption{semver.NextMinor(), semver.EnterChannel("gamma")}, "", semver.ErrUnknownChannel},
+		{"1.0.0-beta.2", []semver.BumpOption{semver.EnterChannel("rc")}, "1.0.0-rc.1", nil},
+		{"1.0.0-beta.2", []semver.BumpOption{semver.EnterChannel("alpha")}, "", semver.ErrChannelBackwards},
+		{"1.0.0-nightly.5", []semver.BumpOption{semver.Channels{"nightly", "preview"}.Next()}, "1.0.0-preview.1", nil},
+		{"1.0.0-nightly.5", []semver.BumpOption{semver.Channels{"nightly", "preview"}.To("nightly")}, "1.0.0-nightly.6", nil},
+		{"1.0.0-snapshot.3", []semver.BumpOption{semver.Channels{"snapshot", "rc"}.Next()}, "", semver.ErrChannelBackwards},
+		{"1.0.0-snapshot.3", []semver.BumpOption{semver.Channels{"snapshot", "rc"}.To("rc")}, "", semver.ErrChannelBackwards},
+		{"1.0.0-snapshot.3", []semver.BumpOption{semver.Channels{"snapshot", "rc"}.Enter("rc")}, "", semver.ErrChannelBackwards},
+		{"1.0.0-rc.2", []semver.BumpOption{semver.Channels{"snapshot", "rc"}.Next()}, "1.0.0", nil},
+		{"1.0.0-snapshot.3", []semver.BumpOption{semver.Channels{"snapshot", "rc"}.To("snapshot")}, "1.0.0-snapshot.4", nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s->%s", tt.version, tt.want), func(t *testing.T) {
+			v := semver.MustParse(tt.version)
+			got, err := v.Bump(tt.options...)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Bump() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() returned unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Bump() = %s, want %s", got.String(), tt.want)
+			}
+			if !semver.Less(&v, &got) {
+				t.Errorf("bumped version %s should be greater than %s", got.String(), v.String())
+			}
+		})
+	}
+}
+
+func ExampleChannels_Next() {
+	v := semver.MustParse("1.0.0-alpha.3")
+	for i := 0; i < 3; i++ {
+		v = v.MustBump(semver.NextChannel())
+		fmt.Println(v.String())
+	}
+	// Output:
+	// 1.0.0-beta.1
+	// 1.0.0-rc.1
+	// 1.0.0
+}
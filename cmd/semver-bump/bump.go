@@ -14,7 +14,7 @@ var extraHelp = "\n" +
 
 func main() {
 	flag.CommandLine.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [OPTIONS]... version\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [OPTIONS]... [version]\n", os.Args[0])
 		fmt.Fprint(flag.CommandLine.Output(), extraHelp)
 		flag.PrintDefaults()
 	}
@@ -22,38 +22,50 @@ func main() {
 	var (
 		major, minor, patch, prerelease, release bool
 
+		premajor, preminor, prepatch, pre bool
+		preid                             string
+
 		buildmetadata string
 		keepMetadata  bool
+
+		parsedVersion semver.VersionFlag
 	)
 
+	flag.Var(&parsedVersion, "version", "The `version` to bump. May be given as the only argument instead.")
+
 	flag.BoolVar(&major, "major", false, "Bump to next major version")
 	flag.BoolVar(&minor, "minor", false, "Bump to next minor version")
 	flag.BoolVar(&patch, "patch", false, "Bump to next patch version")
 	flag.BoolVar(&prerelease, "prerelease", false,
 		"Try to upgrade to next prerelese version by incrementing"+
-			" last number component in prerelease tag")
+			" last number component in prerelease tag. Fails if prerelease has no number component.")
 	flag.BoolVar(&release, "release", false, "Strip prerelease from version")
 
+	flag.BoolVar(&premajor, "premajor", false, "Bump to prerelease of next major version, like 'npm version premajor'")
+	flag.BoolVar(&preminor, "preminor", false, "Bump to prerelease of next minor version, like 'npm version preminor'")
+	flag.BoolVar(&prepatch, "prepatch", false, "Bump to prerelease of next patch version, like 'npm version prepatch'")
+	flag.BoolVar(&pre, "pre", false, "Bump to next prerelease version, like 'npm version prerelease'")
+	flag.StringVar(&preid, "preid", "", "Prerelease identifier used by -premajor, -preminor, -prepatch and -pre flags")
+
 	flag.StringVar(&buildmetadata, "meta", "", "Optional build metadata attached to new version. Can be used multiple times.")
 	flag.BoolVar(&keepMetadata, "keep-meta", false, "Do not reset originam metadata when bumping to new version")
 
 	flag.Parse()
 	versions := flag.Args()
 
-	if len(versions) != 1 {
-		fmt.Fprintf(flag.CommandLine.Output(), "expected single argument: version\n")
-		os.Exit(1)
-	}
-
-	version := versions[0]
-
-	parsedVersion, err := semver.Parse(version)
-	if err != nil {
-		fmt.Printf("Invalid version: '%s', %s\n", version, err)
+	switch {
+	case len(versions) == 0 && parsedVersion.IsSet():
+	case len(versions) == 1 && !parsedVersion.IsSet():
+		if err := parsedVersion.Set(versions[0]); err != nil {
+			fmt.Printf("Invalid version: '%s', %s\n", versions[0], err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(flag.CommandLine.Output(), "expected single version given either as argument or with -version flag\n")
 		os.Exit(1)
 	}
+	version := parsedVersion.String()
 
-	var newVersion semver.Version
 	var opts []semver.BumpOption
 
 	if keepMetadata {
@@ -77,11 +89,19 @@ func main() {
 		opts = append(opts, semver.NextPrerelease())
 	case release:
 		opts = append(opts, semver.NextRelease())
+	case premajor:
+		opts = append(opts, semver.PreMajor(preid))
+	case preminor:
+		opts = append(opts, semver.PreMinor(preid))
+	case prepatch:
+		opts = append(opts, semver.PrePatch(preid))
+	case pre:
+		opts = append(opts, semver.PreRelease(preid))
 	default:
 		opts = append(opts, semver.NextPatch())
 	}
 
-	newVersion, err = parsedVersion.Bump(opts...)
+	newVersion, err := parsedVersion.Bump(opts...)
 	if err != nil {
 		fmt.Printf("Bump '%s' failed: %s\n", version, err)
 		os.Exit(1)
@@ -36,6 +36,7 @@ func main() {
 	onlyLast := flag.Bool("1", false, "return only last sorted version")
 	reverse := flag.Bool("r", false, "return versions in reversed order meaning newest first")
 	ignoreErr := flag.Bool("i", false, "skip versions that have invalid format")
+	withBuild := flag.Bool("b", false, "compare build metadata of versions with equal precedence to make order reproducible")
 
 	flag.Parse()
 
@@ -80,7 +81,11 @@ func main() {
 		os.Exit(0)
 	}
 
-	sortVersions(vs, *reverse)
+	var data sort.Interface = vs
+	if *withBuild {
+		data = versionsWithBuild{vs}
+	}
+	sortVersions(data, *reverse)
 
 	if *onlyLast {
 		vs = vs[len(vs)-1:]
@@ -132,6 +137,14 @@ func (v versions) Swap(i, j int) {
 	v[i], v[j] = v[j], v[i]
 }
 
+type versionsWithBuild struct {
+	versions
+}
+
+func (v versionsWithBuild) Less(i, j int) bool {
+	return semver.LessWithBuild(&v.versions[i], &v.versions[j])
+}
+
 func sortVersions(data sort.Interface, reverse bool) {
 	if reverse {
 		data = sort.Reverse(data)
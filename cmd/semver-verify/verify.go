@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -25,6 +26,12 @@ func main() {
 
 	for _, version := range versions {
 		_, err := semver.Parse(version)
+		var pe *semver.ParseError
+		if errors.As(err, &pe) {
+			fmt.Printf("Invalid version: '%s'\n%s\n\n", version, pe.Diagnostic())
+			wasInvalid = true
+			continue
+		}
 		if err != nil {
 			fmt.Printf("Invalid version: '%s', %s\n", version, err)
 			wasInvalid = true
@@ -0,0 +1,603 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a set of requirements that version must satisfy. It is a union (logical or) of comparator sets,
+// each of them being an intersection (logical and) of simple comparisons like `>=1.2.3` or `<2.0.0`.
+type Constraint struct {
+	sets [][]comparator
+}
+
+type operator string
+
+const (
+	opEqual        operator = "="
+	opLess         operator = "<"
+	opLessEqual    operator = "<="
+	opGreater      operator = ">"
+	opGreaterEqual operator = ">="
+)
+
+type comparator struct {
+	op      operator
+	version Version
+	// prereleases, if set, narrows prereleases that match the comparator; releases are not affected
+	prereleases *span
+}
+
+func (c *comparator) match(v *Version) bool {
+	if c.prereleases != nil && len(v.Prerelease) > 0 && !c.prereleases.contains(v) {
+		return false
+	}
+	cmp := Compare(v, &c.version)
+	switch c.op {
+	case opEqual:
+		return cmp == 0
+	case opLess:
+		return cmp < 0
+	case opLessEqual:
+		return cmp <= 0
+	case opGreater:
+		return cmp > 0
+	case opGreaterEqual:
+		return cmp >= 0
+	}
+	return false
+}
+
+// span returns half-open interval of versions matching the comparator
+func (c *comparator) span() span {
+	v := withoutBuild(&c.version)
+	switch c.op {
+	case opEqual:
+		return span{lower: v, upper: successor(v)}
+	case opLess:
+		return span{upper: v}
+	case opLessEqual:
+		return span{upper: successor(v)}
+	case opGreater:
+		return span{lower: successor(v)}
+	default:
+		return span{lower: v}
+	}
+}
+
+func (c *comparator) String() string {
+	return string(c.op) + c.version.String()
+}
+
+// ParseConstraint parses version requirement written in grammar used by npm (https://github.com/npm/node-semver).
+// Supported are:
+//
+// * primitive comparators: `=1.2.3`, `>1.2.3`, `>=1.2.3`, `<1.2.3`, `<=1.2.3`
+//
+// * x-ranges: `*`, `1.x`, `1.2.*`, `1`, `1.2`
+//
+// * tilde ranges: `~1.2.3`, `~1.2`, `~1`
+//
+// * caret ranges: `^1.2.3`, `^0.2.3`, `^0.0.3`, `^1.x`
+//
+// * hyphen ranges: `1.2.3 - 2.3.4`
+//
+// Comparators separated by whitespace must all be satisfied, while sets separated by `||` are alternatives.
+// Constraints written in other dialects may be parsed with Dialect.Parse.
+func ParseConstraint(s string) (Constraint, error) {
+	return Npm.Parse(s)
+}
+
+// Dialect selects syntax and semantics of constraints as they differ between package managers
+type Dialect int
+
+const (
+	// Npm dialect follows node-semver grammar, see ParseConstraint for details
+	Npm Dialect = iota
+	// Cargo dialect follows rules of Rust package manager (https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html):
+	//
+	// * comparators are separated with comma and all of them must be satisfied; there are no alternatives
+	//
+	// * version without operator is a caret requirement: `1.2` means `^1.2`
+	//
+	// * supported operators are `^`, `~`, `=`, `>`, `>=`, `<` and `<=`
+	//
+	// * wildcards are allowed only in place of trailing numbers: `*`, `1.*`, `1.2.*`
+	//
+	// * comparators with omitted or wildcard numbers, except caret ones, never match prereleases of versions they
+	// match by their numbers: `>=1.2` matches `1.2.3` but neither `1.2.3-rc.1` nor `1.2.0-rc.1`, while it matches
+	// `1.3.0-rc.1` if it is allowed by other comparator; `^1.2` matches prereleases from `1.2.0-0` up. String
+	// shows bounds of such comparators only.
+	Cargo
+)
+
+// Parse parses constraint using rules of the dialect
+func (d Dialect) Parse(s string) (Constraint, error) {
+	var c Constraint
+	var err error
+	switch d {
+	case Npm:
+		c, err = parseNpm(s)
+	case Cargo:
+		c, err = parseCargo(s)
+	default:
+		return Constraint{}, fmt.Errorf("unknown constraint dialect: %d", d)
+	}
+	return c, withInput(err, s)
+}
+
+func parseNpm(s string) (Constraint, error) {
+	c := Constraint{}
+	pos := 0
+	for _, r := range strings.Split(s, "||") {
+		set, err := parseNpmRange(pos, r)
+		if err != nil {
+			return Constraint{}, err
+		}
+		c.sets = append(c.sets, set)
+		pos += len(r) + len("||")
+	}
+	return c, nil
+}
+
+// MustParseConstraint behaves like ParseConstraint but panics instead of returning an error
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check returns true if version satisfies the constraint.
+//
+// Prerelease versions are matched only if at least one comparator of the matching set refers prerelease of the very
+// same major.minor.patch tuple. For example `1.2.3-alpha.7` satisfies `>1.2.3-alpha.3` but `3.4.5-alpha.9`
+// does not, even though it is greater than `1.2.3-alpha.3` according to precedence rules.
+func (c *Constraint) Check(v *Version) bool {
+	return c.check(v, false)
+}
+
+// checkIncludingPrerelease is Check without the rule on prerelease versions
+func (c *Constraint) checkIncludingPrerelease(v *Version) bool {
+	return c.check(v, true)
+}
+
+func (c *Constraint) check(v *Version, includePrerelease bool) bool {
+	for _, set := range c.sets {
+		if matchSet(set, v, includePrerelease) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSet(set []comparator, v *Version, includePrerelease bool) bool {
+	for i := range set {
+		if !set[i].match(v) {
+			return false
+		}
+	}
+	if len(v.Prerelease) == 0 || includePrerelease {
+		return true
+	}
+	for i := range set {
+		if len(set[i].version.Prerelease) > 0 && sameCore(&set[i].version, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameCore(v1, v2 *Version) bool {
+	return v1.Major == v2.Major && v1.Minor == v2.Minor && v1.Patch == v2.Patch
+}
+
+// Range returns normalized set of versions that match the constraint, so it may be intersected, compared or
+// combined with other constraints and ranges. Range contains exactly the versions that satisfy Check, including
+// its rule on prereleases: `^1.2.0` gives range of releases `[1.2.0,2.0.0)` with no prereleases at all, while
+// `>=1.2.3-rc.1 <2.0.0` contains releases `[1.2.3,2.0.0)` and prereleases of `1.2.3` from `1.2.3-rc.1` up.
+func (c *Constraint) Range() Range {
+	r := Range{}
+	for _, set := range c.sets {
+		interval, preInterval := []span{{}}, []span{{}}
+		var tuples []span
+		for i := range set {
+			interval = intersectSpans(interval, []span{set[i].span()}, lowest())
+			preInterval = intersectSpans(preInterval, []span{set[i].span()}, lowest())
+			if set[i].prereleases != nil {
+				preInterval = intersectSpans(preInterval, []span{*set[i].prereleases}, lowest())
+			}
+			if v := set[i].version; len(v.Prerelease) > 0 {
+				// all prereleases of the same major.minor.patch tuple
+				tuples = append(tuples, span{lower: firstPrerelease(&v), upper: release(&v)})
+			}
+		}
+		sr := Range{
+			releases:    releaseSpans(interval),
+			prereleases: prereleaseSpans(intersectSpans(preInterval, normalize(tuples, lowest()), lowest())),
+		}
+		r = r.Union(&sr)
+	}
+	return r
+}
+
+// String returns normalized form of the constraint where every range is expanded to primitive comparators.
+func (c *Constraint) String() string {
+	sets := make([]string, 0, len(c.sets))
+	for _, set := range c.sets {
+		if len(set) == 0 {
+			sets = append(sets, "*")
+			continue
+		}
+		cmps := make([]string, 0, len(set))
+		for i := range set {
+			cmps = append(cmps, set[i].String())
+		}
+		sets = append(sets, strings.Join(cmps, " "))
+	}
+	return strings.Join(sets, " || ")
+}
+
+// partial is a version with some of its core numbers possibly omitted or replaced by wildcard.
+// Only first n core numbers are meaningful, remaining ones are set to zero.
+type partial struct {
+	version  Version
+	n        int
+	wildcard bool
+}
+
+type field struct {
+	pos  int
+	text string
+}
+
+// fields splits s around runs of whitespace remembering position of every field in the original stream
+func fields(pos int, s string) []field {
+	var fs []field
+	start := -1
+	for i, r := range s {
+		space := r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		if !space && start < 0 {
+			start = i
+		}
+		if space && start >= 0 {
+			fs = append(fs, field{pos: pos + start, text: s[start:i]})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fs = append(fs, field{pos: pos + start, text: s[start:]})
+	}
+	return fs
+}
+
+const operatorChars = "<>=~^"
+
+// joinOperators glues operators separated by whitespace with following version, eg. `>= 1.2.3`
+func joinOperators(fs []field) []field {
+	var joined []field
+	for i := 0; i < len(fs); i++ {
+		f := fs[i]
+		if strings.Trim(f.text, operatorChars) == "" && i+1 < len(fs) {
+			f.text += fs[i+1].text
+			i++
+		}
+		joined = append(joined, f)
+	}
+	return joined
+}
+
+func splitOperator(s string, ops []string) (op, remain string) {
+	for _, op := range ops {
+		if strings.HasPrefix(s, op) {
+			return op, s[len(op):]
+		}
+	}
+	return "", s
+}
+
+func isWildcard(s string) bool {
+	return s == "*" || s == "x" || s == "X"
+}
+
+func parseNumber(pos int, c Component, s string) error {
+	if s == "" {
+		return positionErr(pos, c, ErrEmptyIdentifier, "unexpected empty version number")
+	}
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			return positionErr(pos+i, c, ErrInvalidCharacter, "unexpected non-numeric character")
+		}
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return positionErr(pos, c, ErrLeadingZero, "unexpected leading zero")
+	}
+	return nil
+}
+
+// parsePartial parses version that may be incomplete or contain wildcards in place of core numbers: `1`, `1.2.x`,
+// `*` etc. Prerelease and build metadata are allowed only if all three core numbers are specified.
+func parsePartial(pos int, s string) (partial, error) {
+	p := partial{version: Version{Major: "0", Minor: "0", Patch: "0", Prerelease: []string{}, Buildmetadata: []string{}}}
+	if s == "" {
+		return p, positionErr(pos, ComponentMajor, ErrUnexpectedEnd, "unexpected end of stream while version was expected")
+	}
+
+	core, qualifier := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, qualifier = s[:i], s[i:]
+	}
+
+	numbers := strings.Split(core, ".")
+	const coreNumbers = 3
+	if len(numbers) > coreNumbers {
+		return p, positionErr(pos+len(strings.Join(numbers[:coreNumbers], ".")), "", ErrExtraData, "unexpected extra data")
+	}
+
+	offset := pos
+	wildcard := false
+	components := []Component{ComponentMajor, ComponentMinor, ComponentPatch}
+	for i, n := range numbers {
+		switch {
+		case isWildcard(n):
+			wildcard = true
+			p.wildcard = true
+		case wildcard:
+			return p, positionErr(offset, components[i], ErrInvalidCharacter, "unexpected version number after wildcard")
+		default:
+			if err := parseNumber(offset, components[i], n); err != nil {
+				return p, err
+			}
+			p.n = i + 1
+		}
+		offset += len(n) + len(".")
+	}
+	if p.n > 0 {
+		p.version.Major = numbers[0]
+	}
+	if p.n > 1 {
+		p.version.Minor = numbers[1]
+	}
+	if p.n > 2 {
+		p.version.Patch = numbers[2]
+	}
+
+	if qualifier != "" {
+		if p.n < coreNumbers {
+			return p, positionErr(pos+len(core), components[p.n], ErrInvalidCharacter,
+				"unexpected prerelease or build metadata in incomplete version")
+		}
+		v, err := Parse(s)
+		if err != nil {
+			return p, shifted(err, pos)
+		}
+		p.version = v
+	}
+	return p, nil
+}
+
+// next returns lowest possible version of the tuple created by incrementing k-th core number of v (0 for major,
+// 1 for minor and 2 for patch) and zeroing all lower ones. Such version is always a prerelease `X.Y.Z-0`
+// so it may be used as exclusive upper bound that rejects all prereleases of that tuple.
+func next(v *Version, k int) Version {
+	n := Version{Major: v.Major, Minor: "0", Patch: "0", Prerelease: []string{"0"}, Buildmetadata: []string{}}
+	switch k {
+	case 0:
+		n.Major, _ = increment(v.Major)
+	case 1:
+		n.Minor, _ = increment(v.Minor)
+	default:
+		n.Minor = v.Minor
+		n.Patch, _ = increment(v.Patch)
+	}
+	return n
+}
+
+// none is a comparator that is never satisfied as there is no version lower than `0.0.0-0`
+func none() comparator {
+	return comparator{op: opLess, version: Version{Major: "0", Minor: "0", Patch: "0", Prerelease: []string{"0"}}}
+}
+
+func parseNpmRange(pos int, s string) ([]comparator, error) {
+	fs := joinOperators(fields(pos, s))
+
+	if len(fs) == 3 && fs[1].text == "-" {
+		return npmHyphen(fs[0], fs[2])
+	}
+
+	set := []comparator{}
+	for _, f := range fs {
+		cmps, err := npmSimple(f)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cmps...)
+	}
+	return set, nil
+}
+
+// trimV strips optional `v` prefix tolerated by npm in front of versions
+func trimV(pos int, s string) (int, string) {
+	if strings.HasPrefix(s, "v") {
+		return pos + 1, s[1:]
+	}
+	return pos, s
+}
+
+var npmOperators = []string{"~>", "<=", ">=", "<", ">", "=", "~", "^"}
+
+func npmSimple(f field) ([]comparator, error) {
+	op, remain := splitOperator(f.text, npmOperators)
+	p, err := parsePartial(trimV(f.pos+len(op), remain))
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "^":
+		return caret(&p), nil
+	case "~", "~>":
+		return tilde(&p), nil
+	default:
+		return xrange(operator(op), &p), nil
+	}
+}
+
+func xrange(op operator, p *partial) []comparator {
+	v := &p.version
+	if p.n == 3 {
+		if op == "" {
+			op = opEqual
+		}
+		return []comparator{{op: op, version: *v}}
+	}
+	if p.n == 0 {
+		if op == opLess || op == opGreater {
+			return []comparator{none()}
+		}
+		return []comparator{}
+	}
+	switch op {
+	case opGreater:
+		lowest := next(v, p.n-1)
+		lowest.Prerelease = []string{}
+		return []comparator{{op: opGreaterEqual, version: lowest}}
+	case opGreaterEqual:
+		return []comparator{{op: opGreaterEqual, version: *v}}
+	case opLess:
+		return []comparator{{op: opLess, version: withZeroPrerelease(v)}}
+	case opLessEqual:
+		return []comparator{{op: opLess, version: next(v, p.n-1)}}
+	default:
+		return []comparator{
+			{op: opGreaterEqual, version: *v},
+			{op: opLess, version: next(v, p.n-1)},
+		}
+	}
+}
+
+func withZeroPrerelease(v *Version) Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: []string{"0"}, Buildmetadata: []string{}}
+}
+
+func tilde(p *partial) []comparator {
+	v := &p.version
+	switch p.n {
+	case 0:
+		return []comparator{}
+	case 1:
+		return []comparator{{op: opGreaterEqual, version: *v}, {op: opLess, version: next(v, 0)}}
+	default:
+		return []comparator{{op: opGreaterEqual, version: *v}, {op: opLess, version: next(v, 1)}}
+	}
+}
+
+func caret(p *partial) []comparator {
+	v := &p.version
+	if p.n == 0 {
+		return []comparator{}
+	}
+	// first non-zero number decides which one is allowed to change; if all of them are zeros then
+	// the last specified one is used
+	k := p.n - 1
+	for i, n := range []string{v.Major, v.Minor, v.Patch}[:p.n] {
+		if n != "0" {
+			k = i
+			break
+		}
+	}
+	return []comparator{{op: opGreaterEqual, version: *v}, {op: opLess, version: next(v, k)}}
+}
+
+func npmHyphen(from, to field) ([]comparator, error) {
+	lower, err := parsePartial(trimV(from.pos, from.text))
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parsePartial(trimV(to.pos, to.text))
+	if err != nil {
+		return nil, err
+	}
+	set := []comparator{}
+	if lower.n > 0 {
+		set = append(set, comparator{op: opGreaterEqual, version: lower.version})
+	}
+	switch upper.n {
+	case 0:
+	case 3:
+		set = append(set, comparator{op: opLessEqual, version: upper.version})
+	default:
+		set = append(set, comparator{op: opLess, version: next(&upper.version, upper.n-1)})
+	}
+	return set, nil
+}
+
+var cargoOperators = []string{"<=", ">=", "<", ">", "=", "~", "^"}
+
+func parseCargo(s string) (Constraint, error) {
+	set := []comparator{}
+	pos := 0
+	for _, r := range strings.Split(s, ",") {
+		cmps, err := cargoComparator(pos, r)
+		if err != nil {
+			return Constraint{}, err
+		}
+		set = append(set, cmps...)
+		pos += len(r) + len(",")
+	}
+	return Constraint{sets: [][]comparator{set}}, nil
+}
+
+func cargoComparator(pos int, s string) ([]comparator, error) {
+	fs := joinOperators(fields(pos, s))
+	if len(fs) == 0 {
+		return nil, positionErr(pos+len(s), "", ErrUnexpectedEnd, "unexpected empty comparator")
+	}
+	if len(fs) > 1 {
+		return nil, positionErr(fs[1].pos, "", ErrExtraData, "unexpected extra data")
+	}
+
+	op, remain := splitOperator(fs[0].text, cargoOperators)
+	p, err := parsePartial(fs[0].pos+len(op), remain)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.n > 0 && p.n < 3:
+		return cargoPartial(op, &p), nil
+	case op == "^", op == "" && !p.wildcard:
+		return caret(&p), nil
+	case op == "~":
+		return tilde(&p), nil
+	default:
+		return xrange(operator(op), &p), nil
+	}
+}
+
+// cargoPartial returns comparators of version with omitted or wildcard numbers the way Cargo matches them: prerelease
+// of version that has the same leading numbers as the partial one never matches, except for caret comparators that
+// match all prereleases within their bounds
+func cargoPartial(op string, p *partial) []comparator {
+	v := &p.version
+	upper := next(v, p.n-1)
+	switch {
+	case op == "^", op == "" && !p.wildcard:
+		cmps := caret(p)
+		cmps[0].version = withZeroPrerelease(v)
+		return cmps
+	case op == ">":
+		return []comparator{{op: opGreaterEqual, version: upper}}
+	case op == ">=":
+		return []comparator{{op: opGreaterEqual, version: *v, prereleases: &span{lower: &upper}}}
+	case op == "<":
+		return []comparator{{op: opLess, version: withZeroPrerelease(v)}}
+	case op == "<=":
+		lower := withZeroPrerelease(v)
+		return []comparator{{op: opLess, version: upper, prereleases: &span{upper: &lower}}}
+	default:
+		return []comparator{
+			{op: opGreaterEqual, version: *v, prereleases: &span{lower: &upper}},
+			{op: opLess, version: upper},
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package semver_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.3+build", true},
+		{"1.2.3", "1.2.4", false},
+		{">1.2.3", "1.2.4", true},
+		{">1.2.3", "1.2.3", false},
+		{">=1.2.3", "1.2.3", true},
+		{"<1.2.3", "1.2.2", true},
+		{"<=1.2.3", "1.2.3", true},
+		{"<=1.2.3", "1.2.4", false},
+		{">= 1.2.3 < 2", "1.9.9", true},
+		{"v1.2.3", "1.2.3", true},
+
+		{"*", "0.0.0", true},
+		{"", "12.3.4", true},
+		{"*", "1.0.0-rc.1", false},
+		{"1.x", "1.99.0", true},
+		{"1.x", "2.0.0", false},
+		{"1.2.*", "1.2.9", true},
+		{"1.2.*", "1.3.0", false},
+		{"1", "1.0.0", true},
+		{"1.2", "1.2.0", true},
+		{"1.2", "1.1.9", false},
+		{">1", "1.9.9", false},
+		{">1", "2.0.0", true},
+		{">1.2", "1.3.0", true},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0-rc.1", false},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"<*", "0.0.0", false},
+
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"~>1.2.3", "1.2.5", true},
+		{"~1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"~1.2.3-beta.2", "1.2.4-beta.2", false},
+
+		{"^1.2.3", "1.9.9", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0-rc.1", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.2.x", "1.9.0", true},
+		{"^0.0.x", "0.0.9", true},
+		{"^0.0.x", "0.1.0", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.x", "0.9.0", true},
+		{"^0.x", "1.0.0", false},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"^1.2.3-beta.2", "1.2.4-beta.2", false},
+		{"^1.2.3-beta.2", "1.2.4", true},
+
+		{"1.2.3 - 2.3.4", "1.2.3", true},
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2.3 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3.4", "1.2.0", true},
+		{"1.2.3 - 2.3", "2.3.9", true},
+		{"1.2.3 - 2.3", "2.4.0", false},
+		{"1.2.3 - 2", "2.9.9", true},
+		{"1.2.3 - 2", "3.0.0", false},
+
+		{"^1.2.0 || ~2.0", "1.4.2-rc.1", false},
+		{"^1.2.0 || ~2.0", "1.4.2", true},
+		{"^1.2.0 || ~2.0", "2.0.7", true},
+		{"^1.2.0 || ~2.0", "2.1.0", false},
+		{"<1.0.0 || >=1.4.2-rc.0", "1.4.2-rc.1", true},
+		{">1.2.3-alpha.3", "1.2.3-alpha.7", true},
+		{">1.2.3-alpha.3", "3.4.5-alpha.9", false},
+		{">1.2.3-alpha.3", "3.4.5", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s in %s", tt.version, tt.constraint), func(t *testing.T) {
+			c, err := semver.ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("constraint '%s' should be valid but got '%s' instead", tt.constraint, err)
+			}
+			v := semver.MustParse(tt.version)
+			if got := c.Check(&v); got != tt.want {
+				t.Errorf("Constraint(%s).Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintString(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"1.2.3", "=1.2.3"},
+		{"*", "*"},
+		{"1.x || >=2.5.0 || 5.0.0 - 7.2.3", ">=1.0.0 <2.0.0-0 || >=2.5.0 || >=5.0.0 <=7.2.3"},
+		{"~1.2.3", ">=1.2.3 <1.3.0-0"},
+		{"^0.0.3", ">=0.0.3 <0.0.4-0"},
+		{">1.2", ">=1.3.0"},
+		{"<=1", "<2.0.0-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c := semver.MustParseConstraint(tt.constraint)
+			if got := c.String(); got != tt.want {
+				t.Errorf("Constraint(%s).String() = %s, want %s", tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintInvalid(t *testing.T) {
+	invalid := []string{
+		"1.2.3.4",
+		"01.2.3",
+		"1.2-beta",
+		"1.x.3",
+		">=",
+		">=a.b.c",
+		"^1.2.3-",
+		"1.2.3-01",
+		"1.2.3 || >=x.1",
+		"1.2.x-beta",
+		"1.x.x-rc",
+		"1.2.*+b",
+	}
+	for _, constraint := range invalid {
+		t.Run(constraint, func(t *testing.T) {
+			_, err := semver.ParseConstraint(constraint)
+			if err == nil {
+				t.Fatalf("constraint '%s' should be invalid", constraint)
+			}
+		})
+	}
+}
+
+func ExampleConstraint_Check() {
+	c := semver.MustParseConstraint("^1.2.0 || ~2.0")
+	for _, s := range []string{"1.4.2-rc.1", "1.4.2", "2.0.5", "2.1.0"} {
+		v := semver.MustParse(s)
+		fmt.Printf("%s: %v\n", s, c.Check(&v))
+	}
+	// Output:
+	// 1.4.2-rc.1: false
+	// 1.4.2: true
+	// 2.0.5: true
+	// 2.1.0: false
+}
+
+func TestCargoConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.9.0", true},
+		{"1.2.3", "2.0.0", false},
+		{"1.2", "1.2.0", true},
+		{"1.2", "1.9.9", true},
+		{"1", "1.9.9", true},
+		{"0.2.3", "0.2.9", true},
+		{"0.2.3", "0.3.0", false},
+		{"0.0.3", "0.0.4", false},
+		{"0.0", "0.0.9", true},
+		{"0.0", "0.1.0", false},
+		{"0", "0.9.0", true},
+		{"0", "1.0.0", false},
+		{"^1.2.3", "1.5.0", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"=1.2.3", "1.2.4", false},
+		{"=1.2", "1.2.7", true},
+		{"=1.2", "1.3.0", false},
+		{"=1", "1.7.7", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0", false},
+		{"<=1.2", "1.2.9", true},
+		{"*", "99.0.0", true},
+		{"1.*", "1.9.9", true},
+		{"1.*", "2.0.0", false},
+		{"1.2.*", "1.2.9", true},
+		{"1.2.*", "1.3.0", false},
+		{">= 1.2, < 1.5", "1.4.9", true},
+		{">= 1.2, < 1.5", "1.5.0", false},
+		{">=1.2.3, <2", "2.0.0-alpha", false},
+		{">=1.2.3-alpha.1", "1.2.3-alpha.2", true},
+		{">=1.2.3-alpha.1", "1.2.4-alpha.2", false},
+		{"1.2.3-alpha.1", "1.2.3", true},
+
+		{">=1.2, >=1.2.3-rc.0", "1.2.3-rc.1", false},
+		{">=1.2, =1.2.0-rc.1", "1.2.0-rc.1", false},
+		{">=1.2, =1.3.0-rc.1", "1.3.0-rc.1", true},
+		{"2.*, =2.1.0-1", "2.1.0-1", false},
+		{"=1, =1.0.0-rc", "1.0.0-rc", false},
+		{"~1.2, =1.2.5-rc", "1.2.5-rc", false},
+		{"~1.2.3, =1.2.5-rc", "1.2.5-rc", true},
+		{">1.2, =1.3.0-rc.1", "1.3.0-rc.1", true},
+		{">3.*, =4.0.0-rc.1", "4.0.0-rc.1", true},
+		{"<1.2, =1.1.0-rc.1", "1.1.0-rc.1", true},
+		{"<1.2, =1.2.0-rc.1", "1.2.0-rc.1", false},
+		{"<=1.2, =1.2.5-rc", "1.2.5-rc", false},
+		{"<=1.2, =1.1.5-rc", "1.1.5-rc", true},
+		{"^1.2, =1.2.0-rc", "1.2.0-rc", true},
+		{"1.2, =1.2.0-rc", "1.2.0-rc", true},
+		{"^0.2, =0.2.0-rc", "0.2.0-rc", true},
+		{"^0.2, =0.3.0-rc", "0.3.0-rc", false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s in %s", tt.version, tt.constraint), func(t *testing.T) {
+			c, err := semver.Cargo.Parse(tt.constraint)
+			if err != nil {
+				t.Fatalf("constraint '%s' should be valid but got '%s' instead", tt.constraint, err)
+			}
+			v := semver.MustParse(tt.version)
+			if got := c.Check(&v); got != tt.want {
+				t.Errorf("Constraint(%s).Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+			r := c.Range()
+			if got := r.Check(&v); got != tt.want {
+				t.Errorf("Constraint(%s).Range().Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCargoConstraintInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"1.2,",
+		",1.2",
+		"1.2 || 1.3",
+		"1.2.3 - 2.0.0",
+		"v1.2.3",
+		"1.*.3",
+		">= 1.2 <1.5",
+		"1.2.x-beta",
+	}
+	for _, constraint := range invalid {
+		t.Run(constraint, func(t *testing.T) {
+			_, err := semver.Cargo.Parse(constraint)
+			if err == nil {
+				t.Fatalf("constraint '%s' should be invalid", constraint)
+			}
+		})
+	}
+}
+
+func ExampleDialect_Parse() {
+	v := semver.MustParse("1.5.0")
+	for _, d := range []semver.Dialect{semver.Npm, semver.Cargo} {
+		c, _ := d.Parse("1.2")
+		fmt.Printf("%s: %v\n", c.String(), c.Check(&v))
+	}
+	// Output:
+	// >=1.2.0 <1.3.0-0: false
+	// >=1.2.0-0 <2.0.0-0: true
+}
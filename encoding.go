@@ -0,0 +1,38 @@
+package semver
+
+// MarshalText implements encoding.TextMarshaler interface. Version is encoded as its string form which makes it
+// usable as a value in JSON, YAML, TOML and other formats that support text marshalers. Invalid version
+// can not be marshaled.
+func (semver Version) MarshalText() ([]byte, error) {
+	s := semver.String()
+	if _, err := Parse(s); err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface. Text is parsed with Parse so decoding fails
+// with *ParseError if version is malformed.
+func (semver *Version) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*semver = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler interface encoding module version with `v` prefix
+func (m ModuleVersion) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface using ParseModuleVersion
+func (m *ModuleVersion) UnmarshalText(text []byte) error {
+	v, err := ParseModuleVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
@@ -0,0 +1,85 @@
+package semver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+type config struct {
+	Name    string                    `json:"name"`
+	Version semver.Version            `json:"version"`
+	Min     *semver.Version           `json:"min,omitempty"`
+	Module  semver.ModuleVersion      `json:"module"`
+	Known   map[string]semver.Version `json:"known,omitempty"`
+}
+
+func TestTextMarshaling(t *testing.T) {
+	minimum := semver.MustParse("1.0.0-rc.1")
+	c := config{
+		Name:    "app",
+		Version: semver.MustParse("1.2.3-rc.1+build.5"),
+		Min:     &minimum,
+		Module:  semver.MustParseModuleVersion("v2.0.0+incompatible"),
+		Known:   map[string]semver.Version{"last": semver.MustParse("1.2.2")},
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("marshaling returned unexpected error: %v", err)
+	}
+	want := `{"name":"app","version":"1.2.3-rc.1+build.5","min":"1.0.0-rc.1","module":"v2.0.0+incompatible",` +
+		`"known":{"last":"1.2.2"}}`
+	if string(data) != want {
+		t.Errorf("marshaled: %s, want %s", data, want)
+	}
+
+	var decoded config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshaling returned unexpected error: %v", err)
+	}
+	last := decoded.Known["last"]
+	if decoded.Version.String() != c.Version.String() || decoded.Min.String() != c.Min.String() ||
+		decoded.Module.String() != c.Module.String() || last.String() != "1.2.2" {
+		t.Errorf("decoded: %+v, want %+v", decoded, c)
+	}
+}
+
+func TestTextUnmarshalingInvalid(t *testing.T) {
+	tests := []string{
+		`{"version":"1.02.3"}`,
+		`{"version":"1.2"}`,
+		`{"version":""}`,
+		`{"module":"1.2.3"}`,
+	}
+	for _, data := range tests {
+		t.Run(data, func(t *testing.T) {
+			var c config
+			err := json.Unmarshal([]byte(data), &c)
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) {
+				t.Errorf("expected *ParseError but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestTextMarshalingInvalid(t *testing.T) {
+	v := semver.MustParse("1.2.3")
+	v.Minor = "02"
+	if _, err := v.MarshalText(); err == nil {
+		t.Errorf("expected error marshaling invalid version")
+	}
+}
+
+func ExampleVersion_UnmarshalText() {
+	var c struct {
+		Version semver.Version `json:"version"`
+	}
+	err := json.Unmarshal([]byte(`{"version": "1.2.3-beta.02"}`), &c)
+	fmt.Println(err)
+	// Output:
+	// error at position 11: unexpected leading zero
+}
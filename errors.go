@@ -0,0 +1,173 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Component identifies part of version where parse error has occurred
+type Component string
+
+const (
+	ComponentMajor         Component = "major"
+	ComponentMinor         Component = "minor"
+	ComponentPatch         Component = "patch"
+	ComponentPrerelease    Component = "prerelease"
+	ComponentBuildmetadata Component = "buildmetadata"
+)
+
+// ErrorKind describes the reason of parse error. Every kind is an error itself so it can be used as a sentinel
+// value with errors.Is:
+//
+//	if errors.Is(err, semver.ErrLeadingZero) { ... }
+type ErrorKind int
+
+const (
+	// ErrLeadingZero is reported when numeric identifier starts with zero
+	ErrLeadingZero ErrorKind = iota + 1
+	// ErrInvalidCharacter is reported when character is not allowed at given position
+	ErrInvalidCharacter
+	// ErrUnexpectedEnd is reported when input ends before version is complete
+	ErrUnexpectedEnd
+	// ErrExtraData is reported when there is something more after valid version
+	ErrExtraData
+	// ErrEmptyIdentifier is reported when one of dot separated identifiers is empty
+	ErrEmptyIdentifier
+	// ErrInvalidRange is reported when constraint or range is syntactically correct but does not make sense,
+	// e.g. lower bound of interval is greater than upper one
+	ErrInvalidRange
+)
+
+// Error returns short description of the kind
+func (k ErrorKind) Error() string {
+	switch k {
+	case ErrLeadingZero:
+		return "leading zero"
+	case ErrInvalidCharacter:
+		return "invalid character"
+	case ErrUnexpectedEnd:
+		return "unexpected end"
+	case ErrExtraData:
+		return "extra data"
+	case ErrEmptyIdentifier:
+		return "empty identifier"
+	case ErrInvalidRange:
+		return "invalid range"
+	}
+	return fmt.Sprintf("unknown error kind %d", int(k))
+}
+
+// ParseError is returned when version, constraint or range can not be parsed. It points exact place in the
+// input where problem was found.
+type ParseError struct {
+	// Pos is zero based byte offset in Input where error has occurred; it is equal to length
+	// of Input if it ended unexpectedly
+	Pos int
+	// Input is the string that was parsed
+	Input string
+	// Component is a part of version that is invalid; empty if error is not related to any of them
+	Component Component
+	// Kind is the reason of failure
+	Kind ErrorKind
+
+	msg string
+}
+
+func positionErr(pos int, c Component, k ErrorKind, format string, a ...interface{}) error {
+	return &ParseError{
+		Pos:       pos,
+		Component: c,
+		Kind:      k,
+		msg:       fmt.Sprintf(format, a...),
+	}
+}
+
+// withInput fills input of parse error; other errors are returned unchanged
+func withInput(err error, input string) error {
+	if pe, ok := err.(*ParseError); ok {
+		pe.Input = input
+	}
+	return err
+}
+
+// shifted moves position of parse error by offset; used if string being parsed was a part of longer input
+func shifted(err error, offset int) error {
+	if pe, ok := err.(*ParseError); ok {
+		pe.Pos += offset
+	}
+	return err
+}
+
+// Error returns error with stream position where error has occurred
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("error at position %d: %s", e.Pos, e.msg)
+}
+
+// Unwrap returns kind of the error so errors.Is can be used to check it
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+// Char returns character at error position or false if error is reported at the end of input
+func (e *ParseError) Char() (rune, bool) {
+	if e.Pos < 0 || e.Pos >= len(e.Input) {
+		return 0, false
+	}
+	r, _ := utf8.DecodeRuneInString(e.Input[e.Pos:])
+	return r, true
+}
+
+// Diagnostic returns multi-line, human readable description of the error: the input with a caret pointing
+// offending character and a hint how to fix it, e.g.:
+//
+//	1.02.3
+//	  ^
+//	error at position 2: unexpected leading zero
+//	hint: numeric identifiers must not have leading zeros
+func (e *ParseError) Diagnostic() string {
+	var b strings.Builder
+	b.WriteString(e.Input)
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat(" ", utf8.RuneCountInString(e.Input[:min(max(e.Pos, 0), len(e.Input))])))
+	b.WriteString("^\n")
+	b.WriteString(e.Error())
+	if hint := e.hint(); hint != "" {
+		b.WriteString("\nhint: ")
+		b.WriteString(hint)
+	}
+	return b.String()
+}
+
+func (e *ParseError) hint() string {
+	switch e.Kind {
+	case ErrLeadingZero:
+		return "numeric identifiers must not have leading zeros"
+	case ErrInvalidCharacter:
+		switch e.Component {
+		case ComponentMajor, ComponentMinor, ComponentPatch:
+			return "version core must consist of three dot separated numbers: major.minor.patch"
+		case ComponentPrerelease, ComponentBuildmetadata:
+			return "identifiers may contain only ASCII letters, digits and hyphens [0-9A-Za-z-]"
+		}
+	case ErrUnexpectedEnd:
+		switch e.Component {
+		case ComponentMajor, ComponentMinor, ComponentPatch:
+			return "version core must consist of three dot separated numbers: major.minor.patch"
+		case ComponentPrerelease, ComponentBuildmetadata:
+			return fmt.Sprintf("%s must not be empty", e.Component)
+		}
+	case ErrExtraData:
+		return "remove trailing characters; only prerelease (after '-') and build metadata (after '+') may follow patch number"
+	case ErrEmptyIdentifier:
+		return "dot separated identifiers must not be empty"
+	}
+	return ""
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		version   string
+		pos       int
+		component semver.Component
+		kind      semver.ErrorKind
+	}{
+		{"", 0, semver.ComponentMajor, semver.ErrUnexpectedEnd},
+		{"01.1.1", 0, semver.ComponentMajor, semver.ErrLeadingZero},
+		{"a.1.1", 0, semver.ComponentMajor, semver.ErrInvalidCharacter},
+		{"1.01.1", 2, semver.ComponentMinor, semver.ErrLeadingZero},
+		{"1.1.01", 4, semver.ComponentPatch, semver.ErrLeadingZero},
+		{"1", 1, semver.ComponentMinor, semver.ErrUnexpectedEnd},
+		{"1.2", 3, semver.ComponentPatch, semver.ErrUnexpectedEnd},
+		{"1.2.", 4, semver.ComponentPatch, semver.ErrUnexpectedEnd},
+		{"1,2.3", 1, semver.ComponentMinor, semver.ErrInvalidCharacter},
+		{"1.2.3x", 5, "", semver.ErrExtraData},
+		{"1.2.3.4", 5, "", semver.ErrExtraData},
+		{"1.2.3-", 6, semver.ComponentPrerelease, semver.ErrUnexpectedEnd},
+		{"1.2.3-0123", 6, semver.ComponentPrerelease, semver.ErrLeadingZero},
+		{"1.2.3-rc.01", 9, semver.ComponentPrerelease, semver.ErrLeadingZero},
+		{"1.2.3-a..b", 8, semver.ComponentPrerelease, semver.ErrEmptyIdentifier},
+		{"4.0.0-invalid.~", 14, semver.ComponentPrerelease, semver.ErrInvalidCharacter},
+		{"1.0.0-alpha_beta", 11, semver.ComponentPrerelease, semver.ErrInvalidCharacter},
+		{"1.2.3+", 6, semver.ComponentBuildmetadata, semver.ErrUnexpectedEnd},
+		{"1.2.3+a_b", 7, semver.ComponentBuildmetadata, semver.ErrInvalidCharacter},
+		{"1.2.3-rc.1+b..c", 13, semver.ComponentBuildmetadata, semver.ErrEmptyIdentifier},
+		{"9.8.7+meta+meta", 10, "", semver.ErrExtraData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := semver.Parse(tt.version)
+
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError but got: %v", err)
+			}
+			if pe.Pos != tt.pos {
+				t.Errorf("Pos = %d, want %d", pe.Pos, tt.pos)
+			}
+			if pe.Input != tt.version {
+				t.Errorf("Input = %s, want %s", pe.Input, tt.version)
+			}
+			if pe.Component != tt.component {
+				t.Errorf("Component = %s, want %s", pe.Component, tt.component)
+			}
+			if pe.Kind != tt.kind {
+				t.Errorf("Kind = %v, want %v", pe.Kind, tt.kind)
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestParseErrorChar(t *testing.T) {
+	_, err := semver.Parse("1.2.3-rc.1+ö")
+	var pe *semver.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *ParseError but got: %v", err)
+	}
+	if c, ok := pe.Char(); !ok || c != 'ö' {
+		t.Errorf("Char() = %q, %v, want 'ö', true", c, ok)
+	}
+
+	_, err = semver.Parse("1.2")
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *ParseError but got: %v", err)
+	}
+	if c, ok := pe.Char(); ok {
+		t.Errorf("Char() = %q, %v, want no character at the end of input", c, ok)
+	}
+}
+
+func TestParseErrorInConstraint(t *testing.T) {
+	tests := []struct {
+		input     string
+		parse     func(s string) error
+		pos       int
+		component semver.Component
+		kind      semver.ErrorKind
+	}{
+		{"^1.2.3 || >=2.01", parseConstraint, 14, semver.ComponentMinor, semver.ErrLeadingZero},
+		{">=1.2.3-rc.01", parseConstraint, 11, semver.ComponentPrerelease, semver.ErrLeadingZero},
+		{"[1.0.0,2.0.0-a_b)", parseRange, 14, semver.ComponentPrerelease, semver.ErrInvalidCharacter},
+		{"[2.0.0,1.0.0]", parseRange, 1, "", semver.ErrInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := tt.parse(tt.input)
+
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError but got: %v", err)
+			}
+			if pe.Pos != tt.pos || pe.Input != tt.input || pe.Component != tt.component || pe.Kind != tt.kind {
+				t.Errorf("got error at %d of '%s' in %s: %v, want at %d in %s: %v",
+					pe.Pos, pe.Input, pe.Component, pe.Kind, tt.pos, tt.component, tt.kind)
+			}
+		})
+	}
+}
+
+func parseConstraint(s string) error {
+	_, err := semver.ParseConstraint(s)
+	return err
+}
+
+func parseRange(s string) error {
+	_, err := semver.ParseRange(s)
+	return err
+}
+
+func TestParseErrorDiagnostic(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.2", "1.2\n   ^\nerror at position 3: unexpected end of stream while dot was expected\n" +
+			"hint: version core must consist of three dot separated numbers: major.minor.patch"},
+		{"1.2.3-a..b", "1.2.3-a..b\n        ^\nerror at position 8: unexpected empty prerelease\n" +
+			"hint: dot separated identifiers must not be empty"},
+		{"1.2.3+żółw", "1.2.3+żółw\n      ^\nerror at position 6: invalid character in buildmetadata\n" +
+			"hint: identifiers may contain only ASCII letters, digits and hyphens [0-9A-Za-z-]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := semver.Parse(tt.version)
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError but got: %v", err)
+			}
+			if got := pe.Diagnostic(); got != tt.want {
+				t.Errorf("Diagnostic() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleParseError_Diagnostic() {
+	_, err := semver.Parse("1.02.3")
+	var pe *semver.ParseError
+	if errors.As(err, &pe) {
+		fmt.Println(pe.Diagnostic())
+	}
+	// Output:
+	// 1.02.3
+	//   ^
+	// error at position 2: unexpected leading zero
+	// hint: numeric identifiers must not have leading zeros
+}
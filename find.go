@@ -0,0 +1,79 @@
+package semver
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Match is a version found in text by FindAll
+type Match struct {
+	Version Version
+	// Text is the exact substring that was parsed
+	Text string
+	// Offset is zero based byte offset of version in the text
+	Offset int
+	// Line and Column are one based position of version; column is counted in characters, not bytes
+	Line, Column int
+}
+
+// FindAll returns all valid versions found in text, in order of their appearance. Every match is parsed
+// with Parse so it is guaranteed to be a valid version. Version must start with a digit that does not follow
+// other digit or a dot, so only `1.2.3` is found in `v1.2.3` or `release-1.2.3`. If some text is attached at
+// the end version is trimmed at the last dot, plus or minus sign that makes it valid: `1.2.3` is found in
+// `1.2.3.` or `1.2.3-` but not in `1.2.3x`.
+func FindAll(s string) []Match {
+	var matches []Match
+	line, lineStart := 1, 0
+	for i := 0; i < len(s); {
+		if !versionStart(s, i) {
+			i++
+			continue
+		}
+		m, ok := matchAt(s, i)
+		if !ok {
+			i++
+			continue
+		}
+		for j := strings.IndexByte(s[lineStart:i], '\n'); j >= 0; j = strings.IndexByte(s[lineStart:i], '\n') {
+			lineStart += j + 1
+			line++
+		}
+		m.Line = line
+		m.Column = utf8.RuneCountInString(s[lineStart:i]) + 1
+		matches = append(matches, m)
+		i += len(m.Text)
+	}
+	return matches
+}
+
+// FindAllReader works like FindAll but reads text from r
+func FindAllReader(r io.Reader) ([]Match, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return FindAll(string(data)), nil
+}
+
+func versionStart(s string, i int) bool {
+	return isDigit(s[i]) && (i == 0 || !isDigit(s[i-1]) && s[i-1] != '.')
+}
+
+// matchAt returns the longest valid version starting at position i
+func matchAt(s string, i int) (Match, bool) {
+	end := i
+	for end < len(s) && (isIdentifierChar(rune(s[end])) || s[end] == '.' || s[end] == '+') {
+		end++
+	}
+	for ; end > i; end-- {
+		if end < len(s) && !strings.ContainsRune(".+-", rune(s[end])) && isIdentifierChar(rune(s[end])) {
+			continue
+		}
+		v, err := Parse(s[i:end])
+		if err == nil {
+			return Match{Version: v, Text: s[i:end], Offset: i}, true
+		}
+	}
+	return Match{}, false
+}
@@ -0,0 +1,60 @@
+package semver_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestFindAll(t *testing.T) {
+	type match struct {
+		text                 string
+		offset, line, column int
+	}
+	tests := []struct {
+		name string
+		text string
+		want []match
+	}{
+		{"empty", "", nil},
+		{"no versions", "nothing to see here 1.2 or 3", nil},
+		{"single", "1.2.3", []match{{"1.2.3", 0, 1, 1}}},
+		{"prefixed", "v1.2.3 release-2.0.0-rc.1", []match{{"1.2.3", 1, 1, 2}, {"2.0.0-rc.1", 15, 1, 16}}},
+		{"with metadata", "FROM golang:1.21.0-alpine+sha.1", []match{{"1.21.0-alpine+sha.1", 12, 1, 13}}},
+		{"trailing punctuation", "Released 1.2.3. Then 1.2.4-.", []match{{"1.2.3", 9, 1, 10}, {"1.2.4", 21, 1, 22}}},
+		{"attached text", "1.2.3x 1.2.3abc", nil},
+		{"four numbers", "1.2.3.4", []match{{"1.2.3", 0, 1, 1}}},
+		{"leading zeros", "01.2.3 1.02.3 11.2.3", []match{{"11.2.3", 14, 1, 15}}},
+		{"invalid prerelease trimmed", "1.2.3-rc.01 2.0.0-a..b", []match{{"1.2.3-rc", 0, 1, 1}, {"2.0.0-a", 12, 1, 13}}},
+		{"many lines", "## 1.0.0\n\n* fixed\n\n## 0.9.0-beta (żółw 0.8.0)\n",
+			[]match{{"1.0.0", 3, 1, 4}, {"0.9.0-beta", 22, 5, 4}, {"0.8.0", 42, 5, 21}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []match
+			for _, m := range semver.FindAll(tt.text) {
+				if !semver.Valid(m.Text) || m.Version.String() != m.Text || tt.text[m.Offset:m.Offset+len(m.Text)] != m.Text {
+					t.Errorf("inconsistent match: %+v", m)
+				}
+				got = append(got, match{m.Text, m.Offset, m.Line, m.Column})
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("FindAll(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleFindAllReader() {
+	changelog := strings.NewReader("v2.0.0: breaking change\nv1.2.3: bugfix, see also 1.2.2-rc.1\n")
+	matches, _ := semver.FindAllReader(changelog)
+	for _, m := range matches {
+		fmt.Printf("%d:%d %s\n", m.Line, m.Column, m.Version.String())
+	}
+	// Output:
+	// 1:2 2.0.0
+	// 2:2 1.2.3
+	// 2:26 1.2.2-rc.1
+}
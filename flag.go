@@ -0,0 +1,76 @@
+package semver
+
+// VersionFlag is a command line flag holding version. It implements flag.Value interface and pflag.Value
+// as well so it can be used with both standard library and spf13/pflag:
+//
+//	var minVersion semver.VersionFlag
+//	flag.Var(&minVersion, "min-version", "lowest accepted version")
+//
+// Value is validated with Parse so malformed version is reported as invalid flag value.
+type VersionFlag struct {
+	Version
+}
+
+// String returns version set with the flag or empty string if flag was not set
+func (f *VersionFlag) String() string {
+	if f == nil || f.Version.Major == "" {
+		return ""
+	}
+	return f.Version.String()
+}
+
+// Set implements flag.Value interface
+func (f *VersionFlag) Set(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	f.Version = v
+	return nil
+}
+
+// Type implements pflag.Value interface; it is a name of the value type shown in help text
+func (f *VersionFlag) Type() string {
+	return "version"
+}
+
+// IsSet returns true if flag was set to a version
+func (f *VersionFlag) IsSet() bool {
+	return f.Version.Major != ""
+}
+
+// ConstraintFlag is a command line flag holding constraint written in npm syntax: `^1.2.0 || ~2.0`. Similarly
+// to VersionFlag it implements both flag.Value and pflag.Value interfaces.
+type ConstraintFlag struct {
+	Constraint
+	text string
+	set  bool
+}
+
+// String returns constraint as it was given in command line or empty string if flag was not set
+func (f *ConstraintFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+// Set implements flag.Value interface
+func (f *ConstraintFlag) Set(s string) error {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		return err
+	}
+	f.Constraint, f.text, f.set = c, s, true
+	return nil
+}
+
+// Type implements pflag.Value interface; it is a name of the value type shown in help text
+func (f *ConstraintFlag) Type() string {
+	return "constraint"
+}
+
+// IsSet returns true if flag was set to a constraint, including empty one that matches any version
+func (f *ConstraintFlag) IsSet() bool {
+	return f.set
+}
@@ -0,0 +1,89 @@
+package semver_test
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func newFlagSet() (*flag.FlagSet, *semver.VersionFlag, *semver.ConstraintFlag) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	var (
+		v semver.VersionFlag
+		c semver.ConstraintFlag
+	)
+	fs.Var(&v, "min-version", "lowest accepted version")
+	fs.Var(&c, "constraint", "accepted versions")
+	return fs, &v, &c
+}
+
+func TestVersionFlag(t *testing.T) {
+	fs, v, c := newFlagSet()
+	if v.IsSet() || c.IsSet() {
+		t.Fatalf("flags should not be set before parsing")
+	}
+	err := fs.Parse([]string{"--min-version=1.2.0-rc.1", "-constraint", "^1.2.0 || ~2.0"})
+	if err != nil {
+		t.Fatalf("Parse() returned unexpected error: %v", err)
+	}
+	if !v.IsSet() || v.String() != "1.2.0-rc.1" || v.Version.String() != "1.2.0-rc.1" {
+		t.Errorf("version flag = %q, want 1.2.0-rc.1", v.String())
+	}
+	if !c.IsSet() || c.String() != "^1.2.0 || ~2.0" {
+		t.Errorf("constraint flag = %q, want ^1.2.0 || ~2.0", c.String())
+	}
+	for version, want := range map[string]bool{"1.1.0": false, "1.9.0": true, "2.0.5": true, "2.1.0": false} {
+		parsed := semver.MustParse(version)
+		if got := c.Check(&parsed); got != want {
+			t.Errorf("constraint flag Check(%s) = %v, want %v", version, got, want)
+		}
+	}
+	if v.Type() != "version" || c.Type() != "constraint" {
+		t.Errorf("unexpected flag types: %s, %s", v.Type(), c.Type())
+	}
+}
+
+func TestConstraintFlagEmpty(t *testing.T) {
+	fs, _, c := newFlagSet()
+	if err := fs.Parse([]string{"-constraint="}); err != nil {
+		t.Fatalf("Parse() returned unexpected error: %v", err)
+	}
+	if !c.IsSet() || c.String() != "" {
+		t.Errorf("empty constraint flag IsSet() = %v, String() = %q, want true and empty string", c.IsSet(), c.String())
+	}
+	parsed := semver.MustParse("12.3.4")
+	if !c.Check(&parsed) {
+		t.Errorf("empty constraint flag should match any version")
+	}
+}
+
+func TestVersionFlagInvalid(t *testing.T) {
+	tests := [][]string{
+		{"-min-version=1.2"},
+		{"-min-version", "v1.2.3"},
+		{"-constraint=>=1.2.3 <"},
+		{"-constraint=1.2.x-beta"},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			fs, _, _ := newFlagSet()
+			if err := fs.Parse(args); err == nil {
+				t.Errorf("expected error parsing %v", args)
+			}
+		})
+	}
+}
+
+func TestVersionFlagDefaults(t *testing.T) {
+	fs, _, _ := newFlagSet()
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+	fs.PrintDefaults()
+	if strings.Contains(out.String(), "default") {
+		t.Errorf("unset flags should have no default value in help text:\n%s", out.String())
+	}
+}
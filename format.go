@@ -0,0 +1,106 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter interface. Supported verbs:
+//
+// * `%v`, `%s` - full version as returned by String: `1.2.3-rc.1+build.5`
+//
+// * `%+v` - all components named, with prerelease and build metadata identifiers:
+// `{Major:1 Minor:2 Patch:3 Prerelease:[rc 1] Buildmetadata:[build 5]}`
+//
+// * `%q` - full version as quoted string: `"1.2.3-rc.1+build.5"`
+//
+// * `%#v` - Go syntax: `semver.MustParse("1.2.3-rc.1+build.5")`
+//
+// Width and `-` flag pad the result like they do for strings: `%-12v`. Any other verb is reported as invalid
+// the same way fmt package does it: `%!d(semver.Version=1.2.3)`. Use FormatLayout for other shapes, e.g.
+// version without build metadata.
+//
+// Format is promoted to structs embedding Version, so such struct is formatted as the version alone, with its
+// other fields omitted, unless it implements Format itself like ModuleVersion and VersionFlag do.
+func (semver Version) Format(f fmt.State, verb rune) {
+	formatString(f, verb, "semver.Version", semver.String(), semver.components(), "semver.MustParse")
+}
+
+// Format implements fmt.Formatter interface the same way Version.Format does but with module version string
+// form: `v2.0.0+incompatible`
+func (m ModuleVersion) Format(f fmt.State, verb rune) {
+	formatString(f, verb, "semver.ModuleVersion", m.String(), m.components(), "semver.MustParseModuleVersion")
+}
+
+// Format implements fmt.Formatter interface the same way Version.Format does; unset flag is an empty string
+func (f VersionFlag) Format(s fmt.State, verb rune) {
+	formatString(s, verb, "semver.VersionFlag", f.String(), f.components(), "semver.MustParse")
+}
+
+// components returns version with all its components named, the way fmt formats structs with `%+v`
+func (semver *Version) components() string {
+	type plain Version
+	return fmt.Sprintf("%+v", plain(*semver))
+}
+
+// formatString formats string form of a value; verbose is its form with all components named and constructor
+// is a function that gives the value back from string, used for Go syntax representation
+func formatString(f fmt.State, verb rune, typeName, s, verbose, constructor string) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		s = constructor + "(" + strconv.Quote(s) + ")"
+	case verb == 'v' && f.Flag('+'):
+		s = verbose
+	case verb == 'v' || verb == 's':
+	case verb == 'q':
+		s = strconv.Quote(s)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s=%s)", verb, typeName, s)
+		return
+	}
+	format := "%"
+	if f.Flag('-') {
+		format += "-"
+	}
+	if width, ok := f.Width(); ok {
+		format += strconv.Itoa(width)
+	}
+	fmt.Fprintf(f, format+"s", s)
+}
+
+// FormatLayout returns version rendered according to layout where following placeholders are replaced with
+// parts of the version:
+//
+// * `{major}`, `{minor}`, `{patch}` - core numbers
+//
+// * `{prerelease}`, `{build}` - dot separated prerelease and build metadata identifiers
+//
+// * `{-prerelease}`, `{+build}` - the same prefixed by `-` or `+` respectively, or empty if there are no identifiers
+//
+// * `{version}` - full version as returned by String
+//
+// Everything else, including unknown placeholders, is copied as is. For example layout `v{major}.{minor}` gives
+// `v1.2` for version `1.2.3-rc.1`.
+func (semver *Version) FormatLayout(layout string) string {
+	prerelease := strings.Join(semver.Prerelease, ".")
+	build := strings.Join(semver.Buildmetadata, ".")
+	r := strings.NewReplacer(
+		"{major}", semver.Major,
+		"{minor}", semver.Minor,
+		"{patch}", semver.Patch,
+		"{prerelease}", prerelease,
+		"{build}", build,
+		"{-prerelease}", prefixed("-", prerelease),
+		"{+build}", prefixed("+", build),
+		"{version}", semver.String(),
+	)
+	return r.Replace(layout)
+}
+
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
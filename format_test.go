@@ -0,0 +1,124 @@
+package semver_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestVersion_Format(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+build.5")
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "1.2.3-rc.1+build.5"},
+		{"%+v", "{Major:1 Minor:2 Patch:3 Prerelease:[rc 1] Buildmetadata:[build 5]}"},
+		{"%s", "1.2.3-rc.1+build.5"},
+		{"%q", `"1.2.3-rc.1+build.5"`},
+		{"%#v", `semver.MustParse("1.2.3-rc.1+build.5")`},
+		{"%20v|", "  1.2.3-rc.1+build.5|"},
+		{"%-20v|", "1.2.3-rc.1+build.5  |"},
+		{"%d", "%!d(semver.Version=1.2.3-rc.1+build.5)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, v); got != tt.want {
+				t.Errorf("Sprintf(%q) = %s, want %s", tt.format, got, tt.want)
+			}
+			if got := fmt.Sprintf(tt.format, &v); got != tt.want {
+				t.Errorf("Sprintf(%q) of pointer = %s, want %s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEmbedded(t *testing.T) {
+	m := semver.MustParseModuleVersion("v2.0.0+incompatible")
+	var flag semver.VersionFlag
+	if err := flag.Set("1.2.3+build"); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"module version", m, "v2.0.0+incompatible"},
+		{"module version pointer", &m, "v2.0.0+incompatible"},
+		{"version flag", flag, "1.2.3+build"},
+		{"version flag pointer", &flag, "1.2.3+build"},
+		{"unset version flag", &semver.VersionFlag{}, ""},
+		{"version object", semver.VersionObject{Version: semver.MustParse("1.2.3+build")}, "1.2.3+build"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(tt.value); got != tt.want {
+				t.Errorf("Sprint() = %s, want %s", got, tt.want)
+			}
+			if got := fmt.Sprintf("%q", tt.value); got != `"`+tt.want+`"` {
+				t.Errorf("Sprintf(%%q) = %s, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := fmt.Sprintf("%#v", m); got != `semver.MustParseModuleVersion("v2.0.0+incompatible")` {
+		t.Errorf("Sprintf(%%#v) = %s", got)
+	}
+	if got := fmt.Sprintf("%+v", m); got != "{Major:2 Minor:0 Patch:0 Prerelease:[] Buildmetadata:[incompatible]}" {
+		t.Errorf("Sprintf(%%+v) = %s", got)
+	}
+
+	// Format of embedded Version is promoted to structs that do not implement their own one
+	named := struct {
+		semver.Version
+		Name string
+	}{semver.MustParse("1.2.3"), "core"}
+	if got := fmt.Sprintf("%+v", named); got != "{Major:1 Minor:2 Patch:3 Prerelease:[] Buildmetadata:[]}" {
+		t.Errorf("Sprintf(%%+v) of embedding struct = %s", got)
+	}
+	if got := fmt.Sprintf("%v", struct {
+		V    semver.Version
+		Name string
+	}{semver.MustParse("1.2.3"), "core"}); got != "{1.2.3 core}" {
+		t.Errorf("Sprintf(%%v) of struct with version field = %s", got)
+	}
+}
+
+func TestVersion_FormatLayout(t *testing.T) {
+	tests := []struct {
+		version string
+		layout  string
+		want    string
+	}{
+		{"1.2.3-rc.1+build.5", "{major}.{minor}", "1.2"},
+		{"1.2.3-rc.1+build.5", "v{major}.{minor}.{patch}", "v1.2.3"},
+		{"1.2.3-rc.1+build.5", "{major}", "1"},
+		{"1.2.3-rc.1+build.5", "{major}.{minor}.{patch}{-prerelease}", "1.2.3-rc.1"},
+		{"1.2.3", "{major}.{minor}.{patch}{-prerelease}{+build}", "1.2.3"},
+		{"1.2.3-rc.1+build.5", "{prerelease}/{build}", "rc.1/build.5"},
+		{"1.2.3-rc.1+build.5", "release-{version}", "release-1.2.3-rc.1+build.5"},
+		{"1.2.3", "{unknown}-{major", "{unknown}-{major"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			v := semver.MustParse(tt.version)
+			if got := v.FormatLayout(tt.layout); got != tt.want {
+				t.Errorf("FormatLayout(%q) = %s, want %s", tt.layout, got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleVersion_FormatLayout() {
+	v := semver.MustParse("1.2.3-rc.1+build.5")
+	fmt.Println(v.FormatLayout("v{major}.{minor}"))
+	fmt.Println(v.FormatLayout("{major}.{minor}.{patch}{-prerelease}"))
+	fmt.Printf("%v\n%+v\n%#v\n", v, v, v)
+	// Output:
+	// v1.2
+	// 1.2.3-rc.1
+	// 1.2.3-rc.1+build.5
+	// {Major:1 Minor:2 Patch:3 Prerelease:[rc 1] Buildmetadata:[build 5]}
+	// semver.MustParse("1.2.3-rc.1+build.5")
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"math/big"
+)
+
+// Identifier is a single dot separated part of prerelease or build metadata: `rc`, `1`, `x-86`. It is either
+// numeric, consisting of digits only, or alphanumeric. Numeric identifiers have no size limit. Zero value is
+// not a valid identifier; use ParseIdentifier or NumericIdentifier to create one.
+type Identifier struct {
+	s string
+}
+
+// ParseIdentifier returns identifier if string is non-empty and consists of `[0-9A-Za-z-]` characters only.
+// Leading zeros are allowed as they are valid in build metadata; they are refused when identifier is used
+// as prerelease.
+func ParseIdentifier(s string) (Identifier, error) {
+	if s == "" {
+		return Identifier{}, withInput(positionErr(0, "", ErrEmptyIdentifier, "unexpected empty identifier"), s)
+	}
+	for i, r := range s {
+		if !isIdentifierChar(r) {
+			return Identifier{}, withInput(positionErr(i, "", ErrInvalidCharacter, "invalid character in identifier"), s)
+		}
+	}
+	return Identifier{s: s}, nil
+}
+
+// MustParseIdentifier behaves like ParseIdentifier but panics instead of returning an error
+func MustParseIdentifier(s string) Identifier {
+	id, err := ParseIdentifier(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// NumericIdentifier returns numeric identifier of given value
+func NumericIdentifier(n uint64) Identifier {
+	return Identifier{s: fromUint64(n)}
+}
+
+// NumericIdentifierBig returns numeric identifier of given value; ErrNegative is returned if it is lower than zero
+func NumericIdentifierBig(n *big.Int) (Identifier, error) {
+	var s string
+	if err := fromBig(&s, n); err != nil {
+		return Identifier{}, err
+	}
+	return Identifier{s: s}, nil
+}
+
+// IsNumeric returns true if identifier consists of digits only
+func (id Identifier) IsNumeric() bool {
+	return isNum(id.s)
+}
+
+// Big returns value of numeric identifier; false is returned for alphanumeric one
+func (id Identifier) Big() (*big.Int, bool) {
+	if !id.IsNumeric() {
+		return nil, false
+	}
+	n, err := toBig(id.s)
+	return n, err == nil
+}
+
+// Uint64 returns value of numeric identifier; ErrOverflow is returned if it does not fit into uint64 and
+// ErrCorruptedVersion if identifier is not numeric
+func (id Identifier) Uint64() (uint64, error) {
+	return toUint64(id.s)
+}
+
+// String returns identifier as it appears in version string
+func (id Identifier) String() string {
+	return id.s
+}
+
+// Compare returns -1, 0 or 1 depending on whether identifier has lower, equal or higher precedence than the other
+// one. Numeric identifiers are compared as numbers and are always lower than alphanumeric ones which are compared
+// lexically in ASCII order. It is the same order that is used to compare prerelease of versions.
+func (id Identifier) Compare(o Identifier) int {
+	if less, eq := lessOrEqualIdentifier(id.s, o.s); !eq {
+		return order(less)
+	}
+	return 0
+}
+
+// PrereleaseIdentifiers returns prerelease of the version as list of identifiers
+func (semver *Version) PrereleaseIdentifiers() []Identifier {
+	return toIdentifiers(semver.Prerelease)
+}
+
+// BuildIdentifiers returns build metadata of the version as list of identifiers
+func (semver *Version) BuildIdentifiers() []Identifier {
+	return toIdentifiers(semver.Buildmetadata)
+}
+
+// SetPrereleaseIdentifiers replaces prerelease of the version with given identifiers; calling it without any
+// removes prerelease. Numeric identifiers with leading zeros are forbidden in prerelease and *ParseError is
+// returned for them. Zero value identifier is reported the same way.
+func (semver *Version) SetPrereleaseIdentifiers(ids ...Identifier) error {
+	s, err := fromIdentifiers(ids, ComponentPrerelease)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if len(id.s) > 1 && id.s[0] == '0' && id.IsNumeric() {
+			return withInput(positionErr(0, ComponentPrerelease, ErrLeadingZero, "unexpected leading zero"), id.s)
+		}
+	}
+	semver.Prerelease = s
+	return nil
+}
+
+// SetBuildIdentifiers replaces build metadata of the version with given identifiers; calling it without any
+// removes build metadata. Zero value identifier is reported as *ParseError.
+func (semver *Version) SetBuildIdentifiers(ids ...Identifier) error {
+	s, err := fromIdentifiers(ids, ComponentBuildmetadata)
+	if err != nil {
+		return err
+	}
+	semver.Buildmetadata = s
+	return nil
+}
+
+func toIdentifiers(s []string) []Identifier {
+	ids := make([]Identifier, 0, len(s))
+	for _, id := range s {
+		ids = append(ids, Identifier{s: id})
+	}
+	return ids
+}
+
+func fromIdentifiers(ids []Identifier, c Component) ([]string, error) {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id.s == "" {
+			return nil, withInput(positionErr(0, c, ErrEmptyIdentifier, "unexpected empty identifier"), id.s)
+		}
+		s = append(s, id.s)
+	}
+	return s, nil
+}
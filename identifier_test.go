@@ -0,0 +1,127 @@
+package semver_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		s       string
+		numeric bool
+		kind    semver.ErrorKind
+	}{
+		{"rc", false, 0},
+		{"x-86", false, 0},
+		{"0a", false, 0},
+		{"1", true, 0},
+		{"007", true, 0},
+		{"99999999999999999999999", true, 0},
+		{"", false, semver.ErrEmptyIdentifier},
+		{"a.b", false, semver.ErrInvalidCharacter},
+		{"a+b", false, semver.ErrInvalidCharacter},
+		{"ä", false, semver.ErrInvalidCharacter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			id, err := semver.ParseIdentifier(tt.s)
+			if tt.kind != 0 {
+				if !errors.Is(err, tt.kind) {
+					t.Errorf("ParseIdentifier(%q) error = %v, want %v", tt.s, err, tt.kind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIdentifier(%q) returned unexpected error: %v", tt.s, err)
+			}
+			if id.String() != tt.s || id.IsNumeric() != tt.numeric {
+				t.Errorf("ParseIdentifier(%q) = %s (numeric %v), want numeric %v", tt.s, id.String(), id.IsNumeric(), tt.numeric)
+			}
+			if n, ok := id.Big(); ok != tt.numeric || ok && n.String() != trimZeros(tt.s) {
+				t.Errorf("Big() = %v, %v", n, ok)
+			}
+		})
+	}
+}
+
+func trimZeros(s string) string {
+	n, _ := big.NewInt(0).SetString(s, 10)
+	return n.String()
+}
+
+func TestNumericIdentifier(t *testing.T) {
+	id := semver.NumericIdentifier(42)
+	if n, err := id.Uint64(); err != nil || n != 42 || !id.IsNumeric() {
+		t.Errorf("NumericIdentifier(42) = %s, Uint64() = %d, %v", id.String(), n, err)
+	}
+	huge, _ := big.NewInt(0).SetString("18446744073709551616", 10)
+	id, err := semver.NumericIdentifierBig(huge)
+	if err != nil {
+		t.Fatalf("NumericIdentifierBig() returned unexpected error: %v", err)
+	}
+	if _, err := id.Uint64(); !errors.Is(err, semver.ErrOverflow) {
+		t.Errorf("Uint64() error = %v, want ErrOverflow", err)
+	}
+	if _, err := semver.NumericIdentifierBig(big.NewInt(-1)); !errors.Is(err, semver.ErrNegative) {
+		t.Errorf("NumericIdentifierBig(-1) error = %v, want ErrNegative", err)
+	}
+	if _, err := semver.MustParseIdentifier("rc").Uint64(); err == nil {
+		t.Errorf("Uint64() of alphanumeric identifier should return error")
+	}
+}
+
+func TestIdentifier_Compare(t *testing.T) {
+	sorted := []string{"0", "1", "2", "10", "99999999999999999999", "100000000000000000000", "-", "0a", "A", "a", "alpha", "alpha-x", "beta"}
+	for i := range sorted {
+		for j := range sorted {
+			a, b := semver.MustParseIdentifier(sorted[i]), semver.MustParseIdentifier(sorted[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestVersion_Identifiers(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+sha.007")
+	pre := v.PrereleaseIdentifiers()
+	if len(pre) != 2 || pre[0].String() != "rc" || pre[0].IsNumeric() || !pre[1].IsNumeric() {
+		t.Errorf("PrereleaseIdentifiers() = %v", pre)
+	}
+	build := v.BuildIdentifiers()
+	if len(build) != 2 || build[1].String() != "007" || !build[1].IsNumeric() {
+		t.Errorf("BuildIdentifiers() = %v", build)
+	}
+
+	n, _ := pre[1].Uint64()
+	pre[1] = semver.NumericIdentifier(n + 1)
+	if err := v.SetPrereleaseIdentifiers(pre...); err != nil {
+		t.Fatalf("SetPrereleaseIdentifiers() returned unexpected error: %v", err)
+	}
+	if err := v.SetBuildIdentifiers(); err != nil {
+		t.Fatalf("SetBuildIdentifiers() returned unexpected error: %v", err)
+	}
+	if v.String() != "1.2.3-rc.2" {
+		t.Errorf("after setters version = %s, want 1.2.3-rc.2", v.String())
+	}
+
+	if err := v.SetPrereleaseIdentifiers(semver.MustParseIdentifier("007")); !errors.Is(err, semver.ErrLeadingZero) {
+		t.Errorf("SetPrereleaseIdentifiers(007) error = %v, want ErrLeadingZero", err)
+	}
+	if err := v.SetBuildIdentifiers(semver.Identifier{}); !errors.Is(err, semver.ErrEmptyIdentifier) {
+		t.Errorf("SetBuildIdentifiers of zero identifier error = %v, want ErrEmptyIdentifier", err)
+	}
+	if v.String() != "1.2.3-rc.2" {
+		t.Errorf("version should not change on error, got %s", v.String())
+	}
+}
@@ -0,0 +1,182 @@
+package semver
+
+import (
+	"strings"
+)
+
+// Immutable is a version that can not be modified once created and is always valid. Contrary to Version its
+// fields are not exported: it can only be created with validating constructors and every modification returns
+// new copy leaving the original untouched. Zero value is a valid version `0.0.0`.
+type Immutable struct {
+	major, minor, patch string
+	prerelease, build   []string
+}
+
+// FromParts returns immutable version with given core numbers and no prerelease or build metadata
+func FromParts(major, minor, patch uint64) Immutable {
+	return Immutable{major: fromUint64(major), minor: fromUint64(minor), patch: fromUint64(patch)}
+}
+
+// FromVersion returns immutable copy of the version; ErrCorruptedVersion is returned if version is not valid
+func FromVersion(v *Version) (Immutable, error) {
+	if !v.Valid() {
+		return Immutable{}, ErrCorruptedVersion
+	}
+	return Immutable{
+		major:      v.Major,
+		minor:      v.Minor,
+		patch:      v.Patch,
+		prerelease: copyIdentifiers(v.Prerelease),
+		build:      copyIdentifiers(v.Buildmetadata),
+	}, nil
+}
+
+// ParseImmutable parses version string the same way as Parse does but returns immutable version
+func ParseImmutable(s string) (Immutable, error) {
+	v, err := Parse(s)
+	if err != nil {
+		return Immutable{}, err
+	}
+	return FromVersion(&v)
+}
+
+// MustParseImmutable behaves like ParseImmutable but panics instead of returning an error
+func MustParseImmutable(s string) Immutable {
+	i, err := ParseImmutable(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// Major returns major number
+func (i Immutable) Major() string {
+	return orZero(i.major)
+}
+
+// Minor returns minor number
+func (i Immutable) Minor() string {
+	return orZero(i.minor)
+}
+
+// Patch returns patch number
+func (i Immutable) Patch() string {
+	return orZero(i.patch)
+}
+
+// Prerelease returns copy of prerelease identifiers
+func (i Immutable) Prerelease() []string {
+	return copyIdentifiers(i.prerelease)
+}
+
+// Build returns copy of build metadata identifiers
+func (i Immutable) Build() []string {
+	return copyIdentifiers(i.build)
+}
+
+// Version returns mutable copy of the version
+func (i Immutable) Version() Version {
+	return Version{
+		Major:         i.Major(),
+		Minor:         i.Minor(),
+		Patch:         i.Patch(),
+		Prerelease:    i.Prerelease(),
+		Buildmetadata: i.Build(),
+	}
+}
+
+// String returns semver compliant version string
+func (i Immutable) String() string {
+	v := i.Version()
+	return v.String()
+}
+
+// WithMajor returns copy of the version with major number changed
+func (i Immutable) WithMajor(n uint64) Immutable {
+	i.major = fromUint64(n)
+	return i
+}
+
+// WithMinor returns copy of the version with minor number changed
+func (i Immutable) WithMinor(n uint64) Immutable {
+	i.minor = fromUint64(n)
+	return i
+}
+
+// WithPatch returns copy of the version with patch number changed
+func (i Immutable) WithPatch(n uint64) Immutable {
+	i.patch = fromUint64(n)
+	return i
+}
+
+// WithPrerelease returns copy of the version with prerelease identifiers replaced by given ones. Calling it
+// without identifiers removes prerelease. Identifiers are validated and *ParseError is returned for invalid one.
+func (i Immutable) WithPrerelease(ids ...string) (Immutable, error) {
+	parsed, err := parseIdentifiers(ids, ComponentPrerelease, Prerelease)
+	if err != nil {
+		return i, err
+	}
+	i.prerelease = parsed.Prerelease
+	return i, nil
+}
+
+// WithBuild returns copy of the version with build metadata identifiers replaced by given ones. Calling it
+// without identifiers removes build metadata. Identifiers are validated and *ParseError is returned for invalid one.
+func (i Immutable) WithBuild(ids ...string) (Immutable, error) {
+	parsed, err := parseIdentifiers(ids, ComponentBuildmetadata, BuildMetadata)
+	if err != nil {
+		return i, err
+	}
+	i.build = parsed.Buildmetadata
+	return i, nil
+}
+
+// Bump returns copy of the version bumped with given options, see Version.Bump
+func (i Immutable) Bump(options ...BumpOption) (Immutable, error) {
+	v := i.Version()
+	bumped, err := v.Bump(options...)
+	if err != nil {
+		return i, err
+	}
+	return FromVersion(&bumped)
+}
+
+// Compare returns -1, 0 or 1 depending on whether version has lower, equal or higher precedence than the other one
+func (i Immutable) Compare(o Immutable) int {
+	v1, v2 := i.Version(), o.Version()
+	return Compare(&v1, &v2)
+}
+
+// Less returns true if version has lower precedence than the other one
+func (i Immutable) Less(o Immutable) bool {
+	return i.Compare(o) < 0
+}
+
+// parseIdentifiers validates identifiers with given option one by one so identifier containing a dot
+// is not silently split into two
+func parseIdentifiers(ids []string, c Component, option func(string) func(*Version) error) (Version, error) {
+	v := Version{}
+	for _, id := range ids {
+		if id == "" {
+			return v, withInput(positionErr(0, c, ErrEmptyIdentifier, "empty identifier"), id)
+		}
+		if dot := strings.IndexByte(id, '.'); dot >= 0 {
+			return v, withInput(positionErr(dot, c, ErrInvalidCharacter, "unexpected dot inside identifier"), id)
+		}
+		if err := option(id)(&v); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
+func copyIdentifiers(ids []string) []string {
+	return append([]string{}, ids...)
+}
+
+func orZero(n string) string {
+	if n == "" {
+		return "0"
+	}
+	return n
+}
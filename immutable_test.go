@@ -0,0 +1,126 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestImmutable_Zero(t *testing.T) {
+	var i semver.Immutable
+	if i.String() != "0.0.0" {
+		t.Errorf("zero value = %s, want 0.0.0", i.String())
+	}
+	v := i.Version()
+	if !v.Valid() {
+		t.Errorf("zero value should be valid version")
+	}
+}
+
+func TestImmutable_With(t *testing.T) {
+	base := semver.FromParts(1, 2, 3)
+	rc, err := base.WithPrerelease("rc", "1")
+	if err != nil {
+		t.Fatalf("WithPrerelease() returned unexpected error: %v", err)
+	}
+	built, err := rc.WithBuild("sha", "007")
+	if err != nil {
+		t.Fatalf("WithBuild() returned unexpected error: %v", err)
+	}
+	next := built.WithMajor(2).WithMinor(0).WithPatch(0)
+
+	for _, tt := range []struct {
+		got  semver.Immutable
+		want string
+	}{
+		{base, "1.2.3"},
+		{rc, "1.2.3-rc.1"},
+		{built, "1.2.3-rc.1+sha.007"},
+		{next, "2.0.0-rc.1+sha.007"},
+	} {
+		if tt.got.String() != tt.want {
+			t.Errorf("got %s, want %s", tt.got.String(), tt.want)
+		}
+	}
+
+	release, err := next.WithPrerelease()
+	if err != nil {
+		t.Fatalf("WithPrerelease() returned unexpected error: %v", err)
+	}
+	if release.String() != "2.0.0+sha.007" {
+		t.Errorf("WithPrerelease() without identifiers = %s, want 2.0.0+sha.007", release.String())
+	}
+}
+
+func TestImmutable_WithInvalid(t *testing.T) {
+	base := semver.MustParseImmutable("1.2.3-rc.1")
+	tests := []struct {
+		name string
+		f    func() (semver.Immutable, error)
+		kind semver.ErrorKind
+	}{
+		{"leading zero", func() (semver.Immutable, error) { return base.WithPrerelease("01") }, semver.ErrLeadingZero},
+		{"empty", func() (semver.Immutable, error) { return base.WithPrerelease("rc", "") }, semver.ErrEmptyIdentifier},
+		{"dot", func() (semver.Immutable, error) { return base.WithPrerelease("rc.1") }, semver.ErrInvalidCharacter},
+		{"invalid character", func() (semver.Immutable, error) { return base.WithBuild("a_b") }, semver.ErrInvalidCharacter},
+		{"plus", func() (semver.Immutable, error) { return base.WithBuild("a+b") }, semver.ErrExtraData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f()
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("error = %v, want %v", err, tt.kind)
+			}
+			if got.String() != base.String() {
+				t.Errorf("version should not change on error: %s", got.String())
+			}
+		})
+	}
+}
+
+func TestImmutable_CopyOnWrite(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+build")
+	i, err := semver.FromVersion(&v)
+	if err != nil {
+		t.Fatalf("FromVersion() returned unexpected error: %v", err)
+	}
+	v.Prerelease[0] = "changed"
+	i.Prerelease()[0] = "changed"
+	i.Build()[0] = "changed"
+	mutable := i.Version()
+	mutable.Major = "01"
+	if i.String() != "1.2.3-rc.1+build" {
+		t.Errorf("immutable version was modified: %s", i.String())
+	}
+
+	if _, err := semver.FromVersion(&semver.Version{Major: "01", Minor: "0", Patch: "0"}); !errors.Is(err, semver.ErrCorruptedVersion) {
+		t.Errorf("FromVersion() of invalid version error = %v, want ErrCorruptedVersion", err)
+	}
+	if _, err := semver.ParseImmutable("1.2"); err == nil {
+		t.Errorf("expected error parsing invalid version")
+	}
+}
+
+func TestImmutable_BumpAndCompare(t *testing.T) {
+	i := semver.MustParseImmutable("1.2.3-rc.1")
+	bumped, err := i.Bump(semver.NextMinor())
+	if err != nil {
+		t.Fatalf("Bump() returned unexpected error: %v", err)
+	}
+	if bumped.String() != "1.3.0" || i.String() != "1.2.3-rc.1" {
+		t.Errorf("Bump() = %s (original %s), want 1.3.0 (original 1.2.3-rc.1)", bumped.String(), i.String())
+	}
+	if !i.Less(bumped) || bumped.Less(i) || i.Compare(i) != 0 {
+		t.Errorf("1.2.3-rc.1 should be lower than 1.3.0")
+	}
+}
+
+func ExampleImmutable() {
+	v := semver.FromParts(1, 2, 3)
+	rc, _ := v.WithPrerelease("rc", "1")
+	fmt.Println(v, rc, rc.WithMinor(4))
+	// Output:
+	// 1.2.3 1.2.3-rc.1 1.4.3-rc.1
+}
@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarshalJSON implements json.Marshaler interface. Version is encoded as JSON string in its canonical form,
+// e.g. `"1.2.3-rc.1"`. Use VersionObject to encode it as JSON object instead.
+func (semver Version) MarshalJSON() ([]byte, error) {
+	text, err := semver.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. Both string form: `"1.2.3-rc.1"` and object form:
+// `{"major":1,"minor":2,"patch":3,"prerelease":["rc",1],"build":[]}` are accepted.
+func (semver *Version) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case bytes.HasPrefix(data, []byte("{")):
+		return semver.unmarshalObject(data)
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return semver.UnmarshalText([]byte(s))
+}
+
+// VersionObject is a wrapper of Version that is always encoded as JSON object:
+// `{"major":1,"minor":2,"patch":3,"prerelease":["rc",1],"build":["sha","cafebabe"]}`. Numeric prerelease
+// identifiers are encoded as JSON numbers, all other identifiers as strings.
+type VersionObject struct {
+	Version
+}
+
+// MarshalJSON implements json.Marshaler interface encoding version as JSON object
+func (o VersionObject) MarshalJSON() ([]byte, error) {
+	if _, err := o.Version.MarshalText(); err != nil {
+		return nil, err
+	}
+	obj := versionObject{
+		Major:      json.Number(o.Major),
+		Minor:      json.Number(o.Minor),
+		Patch:      json.Number(o.Patch),
+		Prerelease: []interface{}{},
+		Build:      []interface{}{},
+	}
+	for _, id := range o.Prerelease {
+		if isNum(id) {
+			obj.Prerelease = append(obj.Prerelease, json.Number(id))
+			continue
+		}
+		obj.Prerelease = append(obj.Prerelease, id)
+	}
+	for _, id := range o.Buildmetadata {
+		obj.Build = append(obj.Build, id)
+	}
+	return json.Marshal(obj)
+}
+
+type versionObject struct {
+	Major      json.Number   `json:"major"`
+	Minor      json.Number   `json:"minor"`
+	Patch      json.Number   `json:"patch"`
+	Prerelease []interface{} `json:"prerelease"`
+	Build      []interface{} `json:"build"`
+}
+
+func (semver *Version) unmarshalObject(data []byte) error {
+	var obj versionObject
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&obj); err != nil {
+		return err
+	}
+
+	prerelease, err := identifiers("prerelease", obj.Prerelease)
+	if err != nil {
+		return err
+	}
+	build, err := identifiers("build", obj.Build)
+	if err != nil {
+		return err
+	}
+
+	v := Version{Major: obj.Major.String(), Minor: obj.Minor.String(), Patch: obj.Patch.String()}
+	if len(prerelease) > 0 {
+		v.Prerelease = prerelease
+	}
+	if len(build) > 0 {
+		v.Buildmetadata = build
+	}
+	return semver.UnmarshalText([]byte(v.String()))
+}
+
+func identifiers(field string, ids []interface{}) ([]string, error) {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		switch id := id.(type) {
+		case string:
+			s = append(s, id)
+		case json.Number:
+			s = append(s, id.String())
+		default:
+			return nil, fmt.Errorf("invalid %s identifier: %v; expected string or number", field, id)
+		}
+		if strings.ContainsAny(s[len(s)-1], ".+") {
+			return nil, fmt.Errorf("invalid %s identifier: %q; must not contain dots or plus signs", field, s[len(s)-1])
+		}
+	}
+	return s, nil
+}
+
+// MarshalJSON implements json.Marshaler interface encoding module version as JSON string with `v` prefix
+func (m ModuleVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface; only string form is accepted for module versions
+func (m *ModuleVersion) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
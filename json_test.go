@@ -0,0 +1,114 @@
+package semver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	versions := []string{
+		"0.0.0",
+		"1.2.3",
+		"1.2.3-rc.1",
+		"1.2.3-rc.1+build.007",
+		"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+		"99999999999999999999999.999999999999999999.99999999999999999-99999999999999999999",
+		"1.0.0-0A.is.legal",
+	}
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			v := semver.MustParse(version)
+
+			asString, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("marshaling returned unexpected error: %v", err)
+			}
+			if want := `"` + v.String() + `"`; string(asString) != want {
+				t.Errorf("marshaled: %s, want %s", asString, want)
+			}
+			asObject, err := json.Marshal(semver.VersionObject{Version: v})
+			if err != nil {
+				t.Fatalf("marshaling returned unexpected error: %v", err)
+			}
+
+			for _, data := range [][]byte{asString, asObject} {
+				var decoded semver.Version
+				if err := json.Unmarshal(data, &decoded); err != nil {
+					t.Fatalf("unmarshaling %s returned unexpected error: %v", data, err)
+				}
+				if decoded.String() != v.String() {
+					t.Errorf("unmarshaled %s: %s, want %s", data, decoded.String(), v.String())
+				}
+				var decodedObject semver.VersionObject
+				if err := json.Unmarshal(data, &decodedObject); err != nil {
+					t.Fatalf("unmarshaling %s returned unexpected error: %v", data, err)
+				}
+				if decodedObject.String() != v.String() {
+					t.Errorf("unmarshaled %s: %s, want %s", data, decodedObject.String(), v.String())
+				}
+			}
+		})
+	}
+}
+
+func TestJSONObject(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.2.3", `{"major":1,"minor":2,"patch":3,"prerelease":[],"build":[]}`},
+		{"1.2.3-rc.1", `{"major":1,"minor":2,"patch":3,"prerelease":["rc",1],"build":[]}`},
+		{"1.2.3-1a.0+007.sha", `{"major":1,"minor":2,"patch":3,"prerelease":["1a",0],"build":["007","sha"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			data, err := json.Marshal(semver.VersionObject{Version: semver.MustParse(tt.version)})
+			if err != nil {
+				t.Fatalf("marshaling returned unexpected error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("marshaled: %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONUnmarshalInvalid(t *testing.T) {
+	invalid := []string{
+		`"1.2"`,
+		`12`,
+		`true`,
+		`{"major":1,"minor":2}`,
+		`{"major":1,"minor":2,"patch":-3}`,
+		`{"major":1,"minor":2,"patch":3.5}`,
+		`{"major":1,"minor":2,"patch":3,"prerelease":["rc.1"]}`,
+		`{"major":1,"minor":2,"patch":3,"prerelease":[true]}`,
+		`{"major":1,"minor":2,"patch":3,"prerelease":[1.5]}`,
+		`{"major":1,"minor":2,"patch":3,"prerelease":["01"]}`,
+		`{"major":1,"minor":2,"patch":3,"build":["a+b"]}`,
+	}
+	for _, data := range invalid {
+		t.Run(data, func(t *testing.T) {
+			var v semver.Version
+			if err := json.Unmarshal([]byte(data), &v); err == nil {
+				t.Errorf("expected error unmarshaling %s but got: %s", data, v.String())
+			}
+		})
+	}
+}
+
+func ExampleVersionObject() {
+	data, _ := json.Marshal(struct {
+		Plain  semver.Version       `json:"plain"`
+		Object semver.VersionObject `json:"object"`
+	}{
+		Plain:  semver.MustParse("1.2.3-rc.1"),
+		Object: semver.VersionObject{Version: semver.MustParse("1.2.3-rc.1")},
+	})
+	fmt.Println(string(data))
+	// Output:
+	// {"plain":"1.2.3-rc.1","object":{"major":1,"minor":2,"patch":3,"prerelease":["rc",1],"build":[]}}
+}
@@ -0,0 +1,193 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+)
+
+// Tags of binary key encoding. Values are chosen so byte order of encoded keys matches precedence: end of
+// identifiers list goes before any identifier, numeric identifiers before alphanumeric ones and release
+// marker after any prerelease.
+const (
+	keyEnd     byte = 0x00
+	keyNumeric byte = 0x01
+	keyAlnum   byte = 0x02
+	keyRelease byte = 0x03
+)
+
+// keyLengthChunk is the largest length stored in single byte; longer ones are prefixed by 0xFF bytes
+const keyLengthChunk = 0xFF
+
+// ErrCorruptedKey is returned by DecodeKey if key was not produced by AppendKey
+var ErrCorruptedKey = errors.New("corrupted version key")
+
+// AppendKey appends binary key of version to dst and returns extended buffer. Keys compared with bytes.Compare
+// are in the same order as versions compared with CompareWithBuild: by precedence first and by build metadata
+// if precedence is equal. It makes keys suitable for byte-ordered key-value stores.
+//
+// Numbers are stored with their length first so they have no size limit. Keys are self-delimiting so they may
+// be followed by other data and still keep the order. Version is validated and ErrCorruptedVersion is returned
+// if it is not valid.
+func AppendKey(dst []byte, v *Version) ([]byte, error) {
+	if !v.Valid() {
+		return dst, ErrCorruptedVersion
+	}
+	dst = appendKeyNumber(dst, v.Major)
+	dst = appendKeyNumber(dst, v.Minor)
+	dst = appendKeyNumber(dst, v.Patch)
+	if len(v.Prerelease) == 0 {
+		dst = append(dst, keyRelease)
+	} else {
+		for _, id := range v.Prerelease {
+			dst = appendKeyIdentifier(dst, id, false)
+		}
+		dst = append(dst, keyEnd)
+	}
+	for _, id := range v.Buildmetadata {
+		dst = appendKeyIdentifier(dst, id, true)
+	}
+	return append(dst, keyEnd), nil
+}
+
+// appendKeyIdentifier appends tagged identifier. Numeric build identifiers may have leading zeros which are
+// stored after the number itself: value of the number is more significant than their count.
+func appendKeyIdentifier(dst []byte, id string, build bool) []byte {
+	if !isNum(id) {
+		dst = append(dst, keyAlnum)
+		dst = append(dst, id...)
+		return append(dst, keyEnd)
+	}
+	dst = append(dst, keyNumeric)
+	if !build {
+		return appendKeyNumber(dst, id)
+	}
+	trimmed := strings.TrimLeft(id, "0")
+	dst = appendKeyNumber(dst, trimmed)
+	return appendKeyLength(dst, len(id)-len(trimmed))
+}
+
+func appendKeyNumber(dst []byte, n string) []byte {
+	dst = appendKeyLength(dst, len(n))
+	return append(dst, n...)
+}
+
+func appendKeyLength(dst []byte, n int) []byte {
+	for ; n >= keyLengthChunk; n -= keyLengthChunk {
+		dst = append(dst, keyLengthChunk)
+	}
+	return append(dst, byte(n))
+}
+
+// DecodeKey decodes version from the key produced by AppendKey. Bytes following the key are returned as well
+// so keys may be decoded from composite ones. ErrCorruptedKey is returned if key is malformed.
+func DecodeKey(key []byte) (Version, []byte, error) {
+	d := keyDecoder{key: key}
+	v := Version{
+		Major:         d.number(),
+		Minor:         d.number(),
+		Patch:         d.number(),
+		Prerelease:    []string{},
+		Buildmetadata: []string{},
+	}
+	if d.peek() == keyRelease {
+		d.next()
+	} else {
+		for d.err == nil && d.peek() != keyEnd {
+			v.Prerelease = append(v.Prerelease, d.identifier(false))
+		}
+		d.next()
+	}
+	for d.err == nil && d.peek() != keyEnd {
+		v.Buildmetadata = append(v.Buildmetadata, d.identifier(true))
+	}
+	d.next()
+	if d.err != nil {
+		return Version{}, key, d.err
+	}
+	// the only valid key is the one AppendKey would produce for decoded version
+	consumed := key[:len(key)-len(d.key)]
+	if encoded, err := AppendKey(nil, &v); err != nil || string(encoded) != string(consumed) {
+		return Version{}, key, ErrCorruptedKey
+	}
+	return v, d.key, nil
+}
+
+// keyDecoder reads key piece by piece; once error occurs all further reads return zero values
+type keyDecoder struct {
+	key []byte
+	err error
+}
+
+func (d *keyDecoder) peek() byte {
+	if d.err != nil {
+		return keyEnd
+	}
+	if len(d.key) == 0 {
+		d.err = ErrCorruptedKey
+		return keyEnd
+	}
+	return d.key[0]
+}
+
+func (d *keyDecoder) next() byte {
+	b := d.peek()
+	if d.err == nil {
+		d.key = d.key[1:]
+	}
+	return b
+}
+
+func (d *keyDecoder) length() int {
+	n := 0
+	for d.err == nil {
+		b := d.next()
+		n += int(b)
+		if b != keyLengthChunk {
+			break
+		}
+	}
+	return n
+}
+
+func (d *keyDecoder) bytes(n int) string {
+	if d.err != nil {
+		return ""
+	}
+	if n > len(d.key) {
+		d.err = ErrCorruptedKey
+		return ""
+	}
+	s := string(d.key[:n])
+	d.key = d.key[n:]
+	return s
+}
+
+func (d *keyDecoder) number() string {
+	return d.bytes(d.length())
+}
+
+func (d *keyDecoder) identifier(build bool) string {
+	switch d.next() {
+	case keyNumeric:
+		n := d.number()
+		if build {
+			n = strings.Repeat("0", d.length()) + n
+		}
+		return n
+	case keyAlnum:
+		end := len(d.key)
+		for i, b := range d.key {
+			if b == keyEnd {
+				end = i
+				break
+			}
+		}
+		id := d.bytes(end)
+		d.next()
+		return id
+	}
+	if d.err == nil {
+		d.err = ErrCorruptedKey
+	}
+	return ""
+}
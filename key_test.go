@@ -0,0 +1,131 @@
+package semver_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func randomNumber(r *rand.Rand) string {
+	numbers := []string{"0", "1", "2", "9", "10", "11", "99", "100", "18446744073709551616"}
+	if r.Intn(10) == 0 {
+		// long enough to need multiple length bytes
+		return "1" + strings.Repeat("0", 250+r.Intn(10))
+	}
+	return numbers[r.Intn(len(numbers))]
+}
+
+func randomIdentifiers(r *rand.Rand, build bool) []string {
+	alnum := []string{"a", "b", "alpha", "alpha-x", "alphax", "beta", "rc", "-", "0a", "A", "Z9"}
+	ids := make([]string, r.Intn(4))
+	for i := range ids {
+		switch {
+		case r.Intn(2) == 0:
+			ids[i] = alnum[r.Intn(len(alnum))]
+		case build && r.Intn(3) == 0:
+			ids[i] = strings.Repeat("0", 1+r.Intn(2)) + randomNumber(r)
+		default:
+			ids[i] = randomNumber(r)
+		}
+	}
+	return ids
+}
+
+func randomVersion(r *rand.Rand) semver.Version {
+	return semver.Version{
+		Major:         randomNumber(r),
+		Minor:         randomNumber(r),
+		Patch:         randomNumber(r),
+		Prerelease:    randomIdentifiers(r, false),
+		Buildmetadata: randomIdentifiers(r, true),
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestAppendKeyOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		v1, v2 := randomVersion(r), randomVersion(r)
+		if r.Intn(4) == 0 {
+			// share core numbers so prerelease and build get compared more often
+			v2.Major, v2.Minor, v2.Patch = v1.Major, v1.Minor, v1.Patch
+		}
+		k1, err := semver.AppendKey(nil, &v1)
+		if err != nil {
+			t.Fatalf("AppendKey(%s) returned unexpected error: %v", v1.String(), err)
+		}
+		k2, err := semver.AppendKey(nil, &v2)
+		if err != nil {
+			t.Fatalf("AppendKey(%s) returned unexpected error: %v", v2.String(), err)
+		}
+		if got, want := sign(bytes.Compare(k1, k2)), semver.CompareWithBuild(&v1, &v2); got != want {
+			t.Fatalf("bytes.Compare of keys of %s and %s = %d, want %d", v1.String(), v2.String(), got, want)
+		}
+		if semver.Less(&v1, &v2) && bytes.Compare(k1, k2) >= 0 {
+			t.Fatalf("key of %s should be lower than key of %s", v1.String(), v2.String())
+		}
+	}
+}
+
+func TestDecodeKey(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		v := randomVersion(r)
+		key, err := semver.AppendKey([]byte("prefix"), &v)
+		if err != nil {
+			t.Fatalf("AppendKey(%s) returned unexpected error: %v", v.String(), err)
+		}
+		key = append(key, "suffix"...)
+		decoded, rest, err := semver.DecodeKey(key[len("prefix"):])
+		if err != nil {
+			t.Fatalf("DecodeKey of %s returned unexpected error: %v", v.String(), err)
+		}
+		if decoded.String() != v.String() {
+			t.Errorf("DecodeKey() = %s, want %s", decoded.String(), v.String())
+		}
+		if string(rest) != "suffix" {
+			t.Errorf("DecodeKey() rest = %q, want %q", rest, "suffix")
+		}
+	}
+}
+
+func TestDecodeKeyCorrupted(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+build.007")
+	key, err := semver.AppendKey(nil, &v)
+	if err != nil {
+		t.Fatalf("AppendKey returned unexpected error: %v", err)
+	}
+	corrupted := [][]byte{
+		nil,
+		key[:len(key)-1],
+		key[:5],
+		{0x01, '1', 0x01, '0', 0x01, '0', 0x02, '1', 0x00, 0x00, 0x00},
+		{0x02, '0', '1', 0x01, '0', 0x01, '0', 0x03, 0x00},
+		{0x01, '1', 0x01, '0', 0x01, '0', 0x04, 0x00},
+	}
+	for _, k := range corrupted {
+		if _, _, err := semver.DecodeKey(k); !errors.Is(err, semver.ErrCorruptedKey) {
+			t.Errorf("DecodeKey(%v) error = %v, want ErrCorruptedKey", k, err)
+		}
+	}
+}
+
+func TestAppendKeyInvalid(t *testing.T) {
+	v := semver.Version{Major: "01", Minor: "0", Patch: "0"}
+	if _, err := semver.AppendKey(nil, &v); !errors.Is(err, semver.ErrCorruptedVersion) {
+		t.Errorf("AppendKey() error = %v, want ErrCorruptedVersion", err)
+	}
+}
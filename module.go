@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ModuleVersion is a version of Go module (https://go.dev/ref/mod#versions). It is a semantic version with
+// mandatory `v` prefix, like `v1.2.3`. Pseudo-versions: `v0.0.0-20210101120000-abcdef123456` and versions
+// of modules without go.mod file: `v2.0.0+incompatible` are supported as well.
+type ModuleVersion struct {
+	Version
+}
+
+const incompatible = "incompatible"
+
+// ParseModuleVersion parses Go module version. Version must be prefixed with `v` and the only build metadata
+// allowed is `+incompatible`, used only with major versions 2 and above.
+func ParseModuleVersion(s string) (ModuleVersion, error) {
+	if !strings.HasPrefix(s, "v") {
+		return ModuleVersion{}, withInput(positionErr(0, ComponentMajor, ErrInvalidCharacter,
+			"unexpected character in place where v prefix was expected"), s)
+	}
+	v, err := Parse(s[1:])
+	if err != nil {
+		return ModuleVersion{}, withInput(shifted(err, 1), s)
+	}
+	m := ModuleVersion{Version: v}
+	if len(v.Buildmetadata) > 0 && !m.IsIncompatible() {
+		return ModuleVersion{}, withInput(positionErr(strings.Index(s, "+")+1, ComponentBuildmetadata, ErrInvalidCharacter,
+			"build metadata other than +incompatible is not allowed in module version"), s)
+	}
+	if m.IsIncompatible() && (v.Major == "0" || v.Major == "1") {
+		return ModuleVersion{}, withInput(positionErr(strings.Index(s, "+")+1, ComponentBuildmetadata, ErrInvalidCharacter,
+			"+incompatible is not allowed for major version below 2"), s)
+	}
+	return m, nil
+}
+
+// MustParseModuleVersion behaves like ParseModuleVersion but panics instead of returning an error
+func MustParseModuleVersion(s string) ModuleVersion {
+	m, err := ParseModuleVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// String returns module version with `v` prefix
+func (m *ModuleVersion) String() string {
+	return "v" + m.Version.String()
+}
+
+// IsIncompatible returns true if version has `+incompatible` suffix, used by modules with major version 2 or
+// above that do not have go.mod file
+func (m *ModuleVersion) IsIncompatible() bool {
+	return len(m.Buildmetadata) == 1 && m.Buildmetadata[0] == incompatible
+}
+
+// PathMajor returns major version suffix of module path: `/v2` for `v2.1.0`; it is empty for major versions
+// 0 and 1 as well as for incompatible versions
+func (m *ModuleVersion) PathMajor() string {
+	if m.Major == "0" || m.Major == "1" || m.IsIncompatible() {
+		return ""
+	}
+	return "/v" + m.Major
+}
+
+var pseudoVersionRE = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+incompatible)?$`)
+
+// IsPseudo returns true if version is a pseudo-version referring specific revision rather than tagged release,
+// in one of forms: `vX.0.0-yyyymmddhhmmss-abcdefabcdef`, `vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef` or
+// `vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef`
+func (m *ModuleVersion) IsPseudo() bool {
+	return pseudoVersionRE.MatchString(m.String())
+}
+
+const pseudoTimestampLayout = "20060102150405"
+
+// ErrNotPseudo is returned when pseudo-version details are requested from regular version
+var ErrNotPseudo = errors.New("not a pseudo-version")
+
+// PseudoTime returns UTC commit time encoded in pseudo-version
+func (m *ModuleVersion) PseudoTime() (time.Time, error) {
+	timestamp, _, err := m.pseudoParts()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(pseudoTimestampLayout, timestamp)
+}
+
+// PseudoRevision returns commit hash prefix encoded in pseudo-version
+func (m *ModuleVersion) PseudoRevision() (string, error) {
+	_, rev, err := m.pseudoParts()
+	return rev, err
+}
+
+func (m *ModuleVersion) pseudoParts() (timestamp, rev string, err error) {
+	if !m.IsPseudo() {
+		return "", "", ErrNotPseudo
+	}
+	last := m.Prerelease[len(m.Prerelease)-1]
+	i := strings.LastIndex(last, "-")
+	return last[i-len(pseudoTimestampLayout) : i], last[i+1:], nil
+}
+
+// CompareModuleVersions compares module versions the same way as go command does: according to semver
+// precedence, so build metadata including `+incompatible` is ignored
+func CompareModuleVersions(m1, m2 *ModuleVersion) int {
+	return Compare(&m1.Version, &m2.Version)
+}
+
+// SortModuleVersions sorts module versions in order used by `go list -m -versions`: by precedence and, if
+// versions are equal, by their string form
+func SortModuleVersions(ms []ModuleVersion) {
+	sort.Slice(ms, func(i, j int) bool {
+		if cmp := CompareModuleVersions(&ms[i], &ms[j]); cmp != 0 {
+			return cmp < 0
+		}
+		return ms[i].String() < ms[j].String()
+	})
+}
@@ -0,0 +1,117 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestParseModuleVersion(t *testing.T) {
+	tests := []struct {
+		version      string
+		pseudo       bool
+		incompatible bool
+		pathMajor    string
+	}{
+		{"v0.1.0", false, false, ""},
+		{"v1.2.3", false, false, ""},
+		{"v2.0.0", false, false, "/v2"},
+		{"v3.1.0-rc.1", false, false, "/v3"},
+		{"v2.0.0+incompatible", false, true, ""},
+		{"v0.0.0-20210101120000-abcdef123456", true, false, ""},
+		{"v2.0.0-20210101120000-abcdef123456", true, false, "/v2"},
+		{"v1.2.4-0.20210101120000-abcdef123456", true, false, ""},
+		{"v1.2.3-pre.0.20210101120000-abcdef123456", true, false, ""},
+		{"v2.0.1-0.20210101120000-abcdef123456+incompatible", true, true, ""},
+		{"v1.2.3-20210101120000-abcdef123456", false, false, ""},
+		{"v0.0.0-2021010112000-abcdef123456", false, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			m, err := semver.ParseModuleVersion(tt.version)
+			if err != nil {
+				t.Fatalf("version '%s' should be valid but got '%s' instead", tt.version, err)
+			}
+			if m.String() != tt.version {
+				t.Errorf("String() = %s, want %s", m.String(), tt.version)
+			}
+			if m.IsPseudo() != tt.pseudo {
+				t.Errorf("IsPseudo() = %v, want %v", m.IsPseudo(), tt.pseudo)
+			}
+			if m.IsIncompatible() != tt.incompatible {
+				t.Errorf("IsIncompatible() = %v, want %v", m.IsIncompatible(), tt.incompatible)
+			}
+			if m.PathMajor() != tt.pathMajor {
+				t.Errorf("PathMajor() = %s, want %s", m.PathMajor(), tt.pathMajor)
+			}
+		})
+	}
+}
+
+func TestParseModuleVersionInvalid(t *testing.T) {
+	invalid := []string{
+		"1.2.3",
+		"V1.2.3",
+		"v1.2",
+		"v1.2.3+build",
+		"v1.2.3+incompatible",
+		"v2.0.0+incompatible.1",
+	}
+	for _, version := range invalid {
+		t.Run(version, func(t *testing.T) {
+			_, err := semver.ParseModuleVersion(version)
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) || pe.Input != version {
+				t.Fatalf("version '%s' should be invalid, got: %v", version, err)
+			}
+		})
+	}
+}
+
+func TestModuleVersionPseudo(t *testing.T) {
+	m := semver.MustParseModuleVersion("v1.2.4-0.20210304050607-abcdef123456")
+	tm, err := m.PseudoTime()
+	if err != nil || !tm.Equal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Errorf("PseudoTime() = %v, %v", tm, err)
+	}
+	rev, err := m.PseudoRevision()
+	if err != nil || rev != "abcdef123456" {
+		t.Errorf("PseudoRevision() = %s, %v", rev, err)
+	}
+
+	m = semver.MustParseModuleVersion("v1.2.3")
+	if _, err := m.PseudoTime(); !errors.Is(err, semver.ErrNotPseudo) {
+		t.Errorf("PseudoTime() of regular version returned: %v", err)
+	}
+	if _, err := m.PseudoRevision(); !errors.Is(err, semver.ErrNotPseudo) {
+		t.Errorf("PseudoRevision() of regular version returned: %v", err)
+	}
+}
+
+func ExampleSortModuleVersions() {
+	var ms []semver.ModuleVersion
+	for _, s := range []string{
+		"v2.0.0+incompatible",
+		"v1.10.0",
+		"v2.0.0",
+		"v1.2.4-0.20210101120000-abcdef123456",
+		"v1.2.3",
+		"v1.9.0",
+	} {
+		ms = append(ms, semver.MustParseModuleVersion(s))
+	}
+	semver.SortModuleVersions(ms)
+	for _, m := range ms {
+		fmt.Println(m.String())
+	}
+	// Output:
+	// v1.2.3
+	// v1.2.4-0.20210101120000-abcdef123456
+	// v1.9.0
+	// v1.10.0
+	// v2.0.0
+	// v2.0.0+incompatible
+}
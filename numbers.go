@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ErrNegative is returned when negative number is used as version number
+var ErrNegative = errors.New("negative version number")
+
+// MajorUint64 returns major number; ErrOverflow is returned if it does not fit into uint64
+func (semver *Version) MajorUint64() (uint64, error) {
+	return toUint64(semver.Major)
+}
+
+// MinorUint64 returns minor number; ErrOverflow is returned if it does not fit into uint64
+func (semver *Version) MinorUint64() (uint64, error) {
+	return toUint64(semver.Minor)
+}
+
+// PatchUint64 returns patch number; ErrOverflow is returned if it does not fit into uint64
+func (semver *Version) PatchUint64() (uint64, error) {
+	return toUint64(semver.Patch)
+}
+
+// MajorBig returns major number as big integer so there is no limit of its size
+func (semver *Version) MajorBig() (*big.Int, error) {
+	return toBig(semver.Major)
+}
+
+// MinorBig returns minor number as big integer so there is no limit of its size
+func (semver *Version) MinorBig() (*big.Int, error) {
+	return toBig(semver.Minor)
+}
+
+// PatchBig returns patch number as big integer so there is no limit of its size
+func (semver *Version) PatchBig() (*big.Int, error) {
+	return toBig(semver.Patch)
+}
+
+// SetMajor sets major number
+func (semver *Version) SetMajor(n uint64) {
+	semver.Major = fromUint64(n)
+}
+
+// SetMinor sets minor number
+func (semver *Version) SetMinor(n uint64) {
+	semver.Minor = fromUint64(n)
+}
+
+// SetPatch sets patch number
+func (semver *Version) SetPatch(n uint64) {
+	semver.Patch = fromUint64(n)
+}
+
+// SetMajorBig sets major number; ErrNegative is returned if number is lower than zero
+func (semver *Version) SetMajorBig(n *big.Int) error {
+	return fromBig(&semver.Major, n)
+}
+
+// SetMinorBig sets minor number; ErrNegative is returned if number is lower than zero
+func (semver *Version) SetMinorBig(n *big.Int) error {
+	return fromBig(&semver.Minor, n)
+}
+
+// SetPatchBig sets patch number; ErrNegative is returned if number is lower than zero
+func (semver *Version) SetPatchBig(n *big.Int) error {
+	return fromBig(&semver.Patch, n)
+}
+
+// SetMajorString sets major number given as decimal string. It is validated the same way Parse does it so
+// *ParseError is returned if string is not a number or has leading zeros.
+func (semver *Version) SetMajorString(s string) error {
+	return semver.setNumber(s, major())
+}
+
+// SetMinorString sets minor number given as decimal string, see SetMajorString
+func (semver *Version) SetMinorString(s string) error {
+	return semver.setNumber(s, minor())
+}
+
+// SetPatchString sets patch number given as decimal string, see SetMajorString
+func (semver *Version) SetPatchString(s string) error {
+	return semver.setNumber(s, patch())
+}
+
+func (semver *Version) setNumber(s string, number consumer) error {
+	v := *semver
+	if _, err := sequence(number, excess())(0, s, &v); err != nil {
+		return withInput(err, s)
+	}
+	*semver = v
+	return nil
+}
+
+func toUint64(s string) (uint64, error) {
+	if !isNum(s) {
+		return 0, ErrCorruptedVersion
+	}
+	const baseDec, bitSize = 10, 64
+	n, err := strconv.ParseUint(s, baseDec, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s does not fit into uint64", ErrOverflow, s)
+	}
+	return n, nil
+}
+
+func fromUint64(n uint64) string {
+	const baseDec = 10
+	return strconv.FormatUint(n, baseDec)
+}
+
+func toBig(s string) (*big.Int, error) {
+	const baseDec = 10
+	n, ok := big.NewInt(0).SetString(s, baseDec)
+	if !ok || !isNum(s) {
+		return nil, ErrCorruptedVersion
+	}
+	return n, nil
+}
+
+func fromBig(dst *string, n *big.Int) error {
+	if n.Sign() < 0 {
+		return fmt.Errorf("%w: %s", ErrNegative, n)
+	}
+	*dst = n.String()
+	return nil
+}
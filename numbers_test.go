@@ -0,0 +1,119 @@
+package semver_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestVersion_Uint64(t *testing.T) {
+	v := semver.MustParse("18446744073709551615.18446744073709551616.0")
+	major, err := v.MajorUint64()
+	if err != nil || major != 18446744073709551615 {
+		t.Errorf("MajorUint64() = %d, %v, want 18446744073709551615", major, err)
+	}
+	if _, err := v.MinorUint64(); !errors.Is(err, semver.ErrOverflow) {
+		t.Errorf("MinorUint64() error = %v, want ErrOverflow", err)
+	}
+	patch, err := v.PatchUint64()
+	if err != nil || patch != 0 {
+		t.Errorf("PatchUint64() = %d, %v, want 0", patch, err)
+	}
+
+	corrupted := semver.Version{Major: "x", Minor: "-1", Patch: ""}
+	if _, err := corrupted.MajorUint64(); !errors.Is(err, semver.ErrCorruptedVersion) {
+		t.Errorf("MajorUint64() error = %v, want ErrCorruptedVersion", err)
+	}
+	if _, err := corrupted.MinorUint64(); !errors.Is(err, semver.ErrCorruptedVersion) {
+		t.Errorf("MinorUint64() error = %v, want ErrCorruptedVersion", err)
+	}
+	if _, err := corrupted.PatchBig(); !errors.Is(err, semver.ErrCorruptedVersion) {
+		t.Errorf("PatchBig() error = %v, want ErrCorruptedVersion", err)
+	}
+}
+
+func TestVersion_Big(t *testing.T) {
+	v := semver.MustParse("1.99999999999999999999999999.3")
+	major, err := v.MajorBig()
+	if err != nil || major.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("MajorBig() = %v, %v, want 1", major, err)
+	}
+	minor, err := v.MinorBig()
+	if err != nil || minor.String() != "99999999999999999999999999" {
+		t.Errorf("MinorBig() = %v, %v, want 99999999999999999999999999", minor, err)
+	}
+	patch, err := v.PatchBig()
+	if err != nil || patch.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("PatchBig() = %v, %v, want 3", patch, err)
+	}
+}
+
+func TestVersion_Setters(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1")
+	v.SetMajor(4)
+	v.SetMinor(0)
+	v.SetPatch(18446744073709551615)
+	if v.String() != "4.0.18446744073709551615-rc.1" {
+		t.Errorf("after SetMajor/SetMinor/SetPatch: %s", v.String())
+	}
+
+	huge, _ := big.NewInt(0).SetString("123456789012345678901234567890", 10)
+	if err := v.SetMajorBig(huge); err != nil {
+		t.Fatalf("SetMajorBig() returned unexpected error: %v", err)
+	}
+	if err := v.SetMinorBig(big.NewInt(7)); err != nil {
+		t.Fatalf("SetMinorBig() returned unexpected error: %v", err)
+	}
+	if err := v.SetPatchBig(big.NewInt(-1)); !errors.Is(err, semver.ErrNegative) {
+		t.Errorf("SetPatchBig(-1) error = %v, want ErrNegative", err)
+	}
+	if v.String() != "123456789012345678901234567890.7.18446744073709551615-rc.1" {
+		t.Errorf("after SetMajorBig/SetMinorBig: %s", v.String())
+	}
+
+	if err := v.SetMajorString("10"); err != nil {
+		t.Fatalf("SetMajorString() returned unexpected error: %v", err)
+	}
+	if err := v.SetMinorString("0"); err != nil {
+		t.Fatalf("SetMinorString() returned unexpected error: %v", err)
+	}
+	if err := v.SetPatchString("99999999999999999999999"); err != nil {
+		t.Fatalf("SetPatchString() returned unexpected error: %v", err)
+	}
+	if v.String() != "10.0.99999999999999999999999-rc.1" {
+		t.Errorf("after SetMajorString/SetMinorString/SetPatchString: %s", v.String())
+	}
+	if !v.Valid() {
+		t.Errorf("version should be valid after setters: %s", v.String())
+	}
+}
+
+func TestVersion_SetStringInvalid(t *testing.T) {
+	tests := []struct {
+		s    string
+		kind semver.ErrorKind
+	}{
+		{"01", semver.ErrLeadingZero},
+		{"-1", semver.ErrInvalidCharacter},
+		{"1a", semver.ErrExtraData},
+		{"", semver.ErrUnexpectedEnd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			v := semver.MustParse("1.2.3")
+			err := v.SetMinorString(tt.s)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("SetMinorString(%q) error = %v, want %v", tt.s, err, tt.kind)
+			}
+			var pe *semver.ParseError
+			if !errors.As(err, &pe) || pe.Component != "" && pe.Component != semver.ComponentMinor {
+				t.Errorf("SetMinorString(%q) error should be *ParseError of minor component, got %v", tt.s, err)
+			}
+			if v.String() != "1.2.3" {
+				t.Errorf("version should not change on error, got %s", v.String())
+			}
+		})
+	}
+}
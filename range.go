@@ -0,0 +1,586 @@
+package semver
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Range is a set of versions described as union of intervals, like those used by Maven or NuGet:
+// `[1.0.0,2.0.0)`, `(,1.5.0]`, `[1.2.3]`. Contrary to Constraint there are no special rules for prerelease
+// versions: version belongs to the range if it is within any of its intervals according to precedence rules.
+// Range created from Constraint keeps its rules though: `^1.2.0` contains releases from `1.2.0` up to `2.0.0`
+// but none of prereleases in between.
+//
+// That is why releases and prereleases are kept separately, each as a normalized union of intervals: sorted,
+// disjoint and non-adjacent ones, stored as half-open spans `[lower,upper)`. It is possible as each version has
+// its immediate successor: `1.2.4-0` for `1.2.3` and `1.2.3-rc.0` for `1.2.3-rc`, so `(1.2.3,2.0.0]` is exactly
+// `[1.2.4-0,2.0.1-0)`. Bounds of release spans are always releases and bounds of prerelease spans are always
+// prereleases, so two ranges containing the same versions are equal and have the same string representation.
+type Range struct {
+	releases    []span
+	prereleases []span
+}
+
+// span is a half-open interval `[lower,upper)`; nil lower means there is no lower bound (or equivalently
+// it is the lowest possible version) and nil upper that there is no upper bound at all
+type span struct {
+	lower, upper *Version
+}
+
+func (s *span) contains(v *Version) bool {
+	return (s.lower == nil || Compare(v, s.lower) >= 0) && (s.upper == nil || Compare(v, s.upper) < 0)
+}
+
+// empty returns true if span contains no version; floor is the lowest version span may contain
+func (s *span) empty(floor *Version) bool {
+	if s.upper == nil {
+		return false
+	}
+	if s.lower == nil {
+		return Compare(s.upper, floor) <= 0
+	}
+	return Compare(s.lower, s.upper) >= 0
+}
+
+func (s *span) String() string {
+	if s.lower != nil && s.upper != nil && Compare(successor(s.lower), s.upper) == 0 {
+		return "[" + s.lower.String() + "]"
+	}
+	var b strings.Builder
+	switch p := predecessor(s.lower); {
+	case s.lower == nil:
+		b.WriteString("(")
+	case p != nil:
+		b.WriteString("(" + p.String())
+	default:
+		b.WriteString("[" + s.lower.String())
+	}
+	b.WriteString(",")
+	switch p := predecessor(s.upper); {
+	case s.upper == nil:
+		b.WriteString(")")
+	case p != nil:
+		b.WriteString(p.String() + "]")
+	default:
+		b.WriteString(s.upper.String() + ")")
+	}
+	return b.String()
+}
+
+// successor returns the lowest version greater than v ignoring its build metadata
+func successor(v *Version) *Version {
+	s := &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Buildmetadata: []string{}}
+	if len(v.Prerelease) > 0 {
+		s.Prerelease = append(append([]string{}, v.Prerelease...), "0")
+		return s
+	}
+	s.Patch, _ = increment(v.Patch)
+	s.Prerelease = []string{"0"}
+	return s
+}
+
+// predecessor is a reverse of successor; it returns nil if v has no immediate predecessor, e.g. `1.0.0`
+// is preceded by infinite number of prereleases so there is no single version just below it
+func predecessor(v *Version) *Version {
+	if v == nil || len(v.Prerelease) == 0 || v.Prerelease[len(v.Prerelease)-1] != "0" {
+		return nil
+	}
+	p := &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Buildmetadata: []string{}}
+	if len(v.Prerelease) > 1 {
+		p.Prerelease = append([]string{}, v.Prerelease[:len(v.Prerelease)-1]...)
+		return p
+	}
+	if v.Patch == "0" {
+		return nil
+	}
+	p.Patch = decrement(v.Patch)
+	p.Prerelease = []string{}
+	return p
+}
+
+func decrement(n string) string {
+	const baseDec = 10
+	bigN, _ := big.NewInt(0).SetString(n, baseDec)
+	return bigN.Sub(bigN, big.NewInt(1)).String()
+}
+
+// bound is a lower or upper end of interval as written in interval notation; nil version means interval
+// is unbounded on that side
+type bound struct {
+	version   *Version
+	inclusive bool
+}
+
+type interval struct {
+	lower, upper bound
+}
+
+// span converts interval to half-open form
+func (i *interval) span() span {
+	s := span{}
+	switch {
+	case i.lower.version == nil:
+	case i.lower.inclusive:
+		s.lower = withoutBuild(i.lower.version)
+	default:
+		s.lower = successor(i.lower.version)
+	}
+	switch {
+	case i.upper.version == nil:
+	case i.upper.inclusive:
+		s.upper = successor(i.upper.version)
+	default:
+		s.upper = withoutBuild(i.upper.version)
+	}
+	return s
+}
+
+func withoutBuild(v *Version) *Version {
+	return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease, Buildmetadata: []string{}}
+}
+
+// normalize sorts spans, drops empty ones and merges these that overlap or are adjacent; floor is the lowest
+// version spans may contain and lower bound equal to it is replaced with nil
+func normalize(spans []span, floor *Version) []span {
+	var sorted []span
+	for _, s := range spans {
+		if s.empty(floor) {
+			continue
+		}
+		if s.lower != nil && Compare(s.lower, floor) <= 0 {
+			s.lower = nil
+		}
+		sorted = append(sorted, s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return lowerLess(sorted[i].lower, sorted[j].lower)
+	})
+
+	var merged []span
+	for _, s := range sorted {
+		n := len(merged)
+		if n > 0 && (merged[n-1].upper == nil || !lowerLess(merged[n-1].upper, s.lower)) {
+			if upperLess(merged[n-1].upper, s.upper) {
+				merged[n-1].upper = s.upper
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// releaseSpans returns normalized spans containing the same releases as given ones, with all bounds being
+// releases: prerelease bound is replaced by release of its major.minor.patch tuple as there is no other release
+// between them
+func releaseSpans(spans []span) []span {
+	mapped := make([]span, 0, len(spans))
+	for _, s := range spans {
+		mapped = append(mapped, span{lower: release(s.lower), upper: release(s.upper)})
+	}
+	return normalize(mapped, lowestRelease())
+}
+
+// prereleaseSpans returns normalized spans containing the same prereleases as given ones, with all bounds being
+// prereleases: release bound is replaced by its successor as there is no other prerelease between them
+func prereleaseSpans(spans []span) []span {
+	mapped := make([]span, 0, len(spans))
+	for _, s := range spans {
+		if s.lower != nil && len(s.lower.Prerelease) == 0 {
+			s.lower = successor(s.lower)
+		}
+		if s.upper != nil && len(s.upper.Prerelease) == 0 {
+			s.upper = successor(s.upper)
+		}
+		mapped = append(mapped, s)
+	}
+	return normalize(mapped, lowest())
+}
+
+// fromSpans returns range of all versions within given spans
+func fromSpans(spans []span) Range {
+	return Range{releases: releaseSpans(spans), prereleases: prereleaseSpans(spans)}
+}
+
+func release(v *Version) *Version {
+	if v == nil {
+		return nil
+	}
+	return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: []string{}, Buildmetadata: []string{}}
+}
+
+// firstPrerelease returns the lowest prerelease of the same major.minor.patch tuple as v: `X.Y.Z-0`
+func firstPrerelease(v *Version) *Version {
+	return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: []string{"0"}, Buildmetadata: []string{}}
+}
+
+// lowest returns the lowest possible version
+func lowest() *Version {
+	return &Version{Major: "0", Minor: "0", Patch: "0", Prerelease: []string{"0"}, Buildmetadata: []string{}}
+}
+
+// lowestRelease returns the lowest possible release version
+func lowestRelease() *Version {
+	return &Version{Major: "0", Minor: "0", Patch: "0", Prerelease: []string{}, Buildmetadata: []string{}}
+}
+
+// lowerLess compares lower bounds where nil means no bound at all
+func lowerLess(a, b *Version) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return Less(a, b)
+}
+
+// upperLess compares upper bounds where nil means no bound at all
+func upperLess(a, b *Version) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return Less(a, b)
+}
+
+// ParseRange parses range written in interval notation. Range consists of one or more comma separated intervals:
+//
+// * `[1.0.0,2.0.0)` - versions from 1.0.0 (inclusive) to 2.0.0 (exclusive)
+//
+// * `(,1.5.0]` - versions up to 1.5.0 (inclusive)
+//
+// * `(1.0.0,)` - versions greater than 1.0.0
+//
+// * `[1.2.3]` - exactly version 1.2.3
+//
+// Square bracket means bound is inclusive, round one that it is exclusive. Omitted bound makes interval unbounded
+// on that side and must be marked as exclusive. Versions must be complete and valid semantic versions.
+func ParseRange(s string) (Range, error) {
+	input := s
+	var spans []span
+	pos := 0
+	for {
+		i, remain, err := parseInterval(pos, s)
+		if err != nil {
+			return Range{}, withInput(err, input)
+		}
+		spans = append(spans, i.span())
+		pos += len(s) - len(remain)
+		s = remain
+
+		trimmed := strings.TrimLeft(s, " ")
+		pos += len(s) - len(trimmed)
+		s = trimmed
+
+		if s == "" {
+			return fromSpans(spans), nil
+		}
+		if s[0] != ',' {
+			return Range{}, withInput(positionErr(pos, "", ErrInvalidCharacter, "unexpected character in place where comma was expected"), input)
+		}
+		s = s[1:]
+		pos++
+	}
+}
+
+// MustParseRange behaves like ParseRange but panics instead of returning an error
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseInterval(pos int, s string) (i interval, remain string, err error) {
+	trimmed := strings.TrimLeft(s, " ")
+	pos += len(s) - len(trimmed)
+	s = trimmed
+
+	if s == "" {
+		return i, s, positionErr(pos, "", ErrUnexpectedEnd, "unexpected end of stream while interval was expected")
+	}
+	if s[0] != '[' && s[0] != '(' {
+		return i, s, positionErr(pos, "", ErrInvalidCharacter, "unexpected character in place where '[' or '(' was expected")
+	}
+	i.lower.inclusive = s[0] == '['
+
+	end := strings.IndexAny(s, "])")
+	if end < 0 {
+		return i, s, positionErr(pos+len(s), "", ErrUnexpectedEnd, "unexpected end of stream while ']' or ')' was expected")
+	}
+	i.upper.inclusive = s[end] == ']'
+	body, remain := s[1:end], s[end+1:]
+	pos++
+
+	comma := strings.Index(body, ",")
+	if comma < 0 {
+		v, err := parseBound(pos, body)
+		if err != nil {
+			return i, s, err
+		}
+		if v == nil || !i.lower.inclusive || !i.upper.inclusive {
+			return i, s, positionErr(pos-1, "", ErrInvalidRange, "single version interval must be enclosed in square brackets")
+		}
+		i.lower.version, i.upper.version = v, v
+		return i, remain, nil
+	}
+
+	if i.lower.version, err = parseBound(pos, body[:comma]); err != nil {
+		return i, s, err
+	}
+	if i.upper.version, err = parseBound(pos+comma+1, body[comma+1:]); err != nil {
+		return i, s, err
+	}
+	if i.lower.version == nil && i.lower.inclusive {
+		return i, s, positionErr(pos-1, "", ErrInvalidRange, "unbounded interval must be exclusive")
+	}
+	if i.upper.version == nil && i.upper.inclusive {
+		return i, s, positionErr(pos+len(body), "", ErrInvalidRange, "unbounded interval must be exclusive")
+	}
+	if i.lower.version != nil && i.upper.version != nil && Less(i.upper.version, i.lower.version) {
+		return i, s, positionErr(pos, "", ErrInvalidRange, "lower bound of interval is greater than upper one")
+	}
+	if i.lower.version != nil && i.upper.version != nil && Equal(i.lower.version, i.upper.version) &&
+		(!i.lower.inclusive || !i.upper.inclusive) {
+		return i, s, positionErr(pos-1, "", ErrInvalidRange, "interval with equal bounds must be enclosed in square brackets")
+	}
+	return i, remain, nil
+}
+
+// parseBound parses single version of interval; empty string is valid and means there is no bound
+func parseBound(pos int, s string) (*Version, error) {
+	trimmed := strings.TrimLeft(s, " ")
+	pos += len(s) - len(trimmed)
+	s = strings.TrimRight(trimmed, " ")
+	if s == "" {
+		return nil, nil
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return nil, shifted(err, pos)
+	}
+	return &v, nil
+}
+
+// Check returns true if version is within any of the range intervals
+func (r *Range) Check(v *Version) bool {
+	spans := r.releases
+	if len(v.Prerelease) > 0 {
+		spans = r.prereleases
+	}
+	for i := range spans {
+		if spans[i].contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty returns true if there is no version that belongs to the range
+func (r *Range) IsEmpty() bool {
+	return len(r.releases) == 0 && len(r.prereleases) == 0
+}
+
+// Union returns range of versions that belong to any of both ranges
+func (r *Range) Union(o *Range) Range {
+	return Range{
+		releases:    unionSpans(r.releases, o.releases, lowestRelease()),
+		prereleases: unionSpans(r.prereleases, o.prereleases, lowest()),
+	}
+}
+
+// Intersect returns range of versions that belong to both ranges
+func (r *Range) Intersect(o *Range) Range {
+	return Range{
+		releases:    intersectSpans(r.releases, o.releases, lowestRelease()),
+		prereleases: intersectSpans(r.prereleases, o.prereleases, lowest()),
+	}
+}
+
+// Complement returns range of all versions that do not belong to the range
+func (r *Range) Complement() Range {
+	return Range{
+		releases:    complementSpans(r.releases, lowestRelease()),
+		prereleases: complementSpans(r.prereleases, lowest()),
+	}
+}
+
+// IsSubsetOf returns true if every version that belongs to the range belongs to the other one as well
+func (r *Range) IsSubsetOf(o *Range) bool {
+	c := o.Complement()
+	i := r.Intersect(&c)
+	return i.IsEmpty()
+}
+
+// String returns canonical form of the range in interval notation. Ranges containing the same set of versions
+// always have the same representation. Empty range is written as `(,0.0.0-0)`.
+//
+// Range that can not be written in interval notation, like the one created from `^1.2.0` constraint which does
+// not contain prereleases between its bounds, has its releases and prereleases written separately:
+// `releases [1.2.0,2.0.0)`. Part that is omitted is empty.
+func (r *Range) String() string {
+	if plain, ok := r.plain(); ok {
+		if len(plain) == 0 {
+			return "(," + lowest().String() + ")"
+		}
+		return spansString(plain)
+	}
+	var parts []string
+	if len(r.releases) > 0 {
+		parts = append(parts, "releases "+spansString(displayReleases(r.releases)))
+	}
+	if len(r.prereleases) > 0 {
+		parts = append(parts, "prereleases "+spansString(displayPrereleases(r.prereleases)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// displayReleases returns spans of releases with bounds changed to the ones that are more natural when only
+// releases are considered: `[1.2.4,2.0.1)` is written as `[1.2.4,2.0.0]`
+func displayReleases(spans []span) []span {
+	display := make([]span, 0, len(spans))
+	for _, s := range spans {
+		if s.upper != nil && s.upper.Patch != "0" {
+			s.upper = firstPrerelease(s.upper)
+		}
+		display = append(display, s)
+	}
+	return display
+}
+
+// displayPrereleases returns spans of prereleases with bounds changed to the ones that are more natural when only
+// prereleases are considered: `[1.2.3-rc.1,1.2.4-0)` is written as `[1.2.3-rc.1,1.2.3)`
+func displayPrereleases(spans []span) []span {
+	display := make([]span, 0, len(spans))
+	for _, s := range spans {
+		if p := predecessor(s.upper); p != nil && len(p.Prerelease) == 0 {
+			s.upper = p
+		}
+		display = append(display, s)
+	}
+	return display
+}
+
+// plain returns spans containing all versions of the range, both releases and prereleases, and no other ones.
+// False is returned if there are no such spans. Bounds of all spans split versions into segments each of them
+// being entirely in or out of every span; range is plain if no segment has only one kind of its versions in range.
+// Release of every bound tuple is split from prereleases around it, so a single release is a segment of its own.
+// The same is done for first and last releases of each release span as consecutive releases like `[1.0.0],[1.0.1]`
+// are merged into one release span.
+func (r *Range) plain() ([]span, bool) {
+	const maxSplitReleases = 16
+	var bounds []*Version
+	addRelease := func(v *Version) {
+		bounds = append(bounds, firstPrerelease(v), v, successor(v))
+	}
+	addRelease(lowestRelease())
+	for _, s := range r.releases {
+		lower := s.lower
+		if lower == nil {
+			lower = lowestRelease()
+		}
+		for v, n := lower, 0; n < maxSplitReleases && (s.upper == nil || Less(v, s.upper)); n++ {
+			addRelease(v)
+			v = release(successor(v))
+		}
+		for v, n := s.upper, 0; n < maxSplitReleases && v != nil && v.Patch != "0" && Less(lower, v); n++ {
+			v = release(v)
+			v.Patch = decrement(v.Patch)
+			addRelease(v)
+		}
+	}
+	for _, spans := range [][]span{r.releases, r.prereleases} {
+		for _, s := range spans {
+			for _, b := range []*Version{s.lower, s.upper} {
+				if b == nil {
+					continue
+				}
+				// release is also a border of prerelease span: `[1.0.0,2.0.0)` has prereleases up to `2.0.1-0`
+				bounds = append(bounds, b)
+				addRelease(release(b))
+				if p := predecessor(b); p != nil && len(p.Prerelease) == 0 {
+					addRelease(p)
+				}
+			}
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool {
+		return Less(bounds[i], bounds[j])
+	})
+
+	var spans []span
+	var lower *Version
+	for i := 0; i <= len(bounds); i++ {
+		segment := span{lower: lower}
+		if i < len(bounds) {
+			segment.upper = bounds[i]
+			lower = bounds[i]
+		}
+		hasReleases := len(releaseSpans([]span{segment})) > 0
+		hasPrereleases := len(prereleaseSpans([]span{segment})) > 0
+		inReleases, inPrereleases := covers(r.releases, segment), covers(r.prereleases, segment)
+		if hasReleases && hasPrereleases && inReleases != inPrereleases {
+			return nil, false
+		}
+		if hasReleases && inReleases || hasPrereleases && inPrereleases {
+			spans = append(spans, segment)
+		}
+	}
+	return normalize(spans, lowest()), true
+}
+
+// covers returns true if segment is within any of spans
+func covers(spans []span, segment span) bool {
+	for _, s := range spans {
+		if !lowerLess(segment.lower, s.lower) && !upperLess(s.upper, segment.upper) {
+			return true
+		}
+	}
+	return false
+}
+
+func unionSpans(a, b []span, floor *Version) []span {
+	spans := make([]span, 0, len(a)+len(b))
+	spans = append(spans, a...)
+	spans = append(spans, b...)
+	return normalize(spans, floor)
+}
+
+func intersectSpans(a, b []span, floor *Version) []span {
+	var spans []span
+	for i := range a {
+		for j := range b {
+			s := a[i]
+			if lowerLess(s.lower, b[j].lower) {
+				s.lower = b[j].lower
+			}
+			if upperLess(b[j].upper, s.upper) {
+				s.upper = b[j].upper
+			}
+			spans = append(spans, s)
+		}
+	}
+	return normalize(spans, floor)
+}
+
+func complementSpans(a []span, floor *Version) []span {
+	var spans []span
+	var lower *Version
+	for i, s := range a {
+		if i > 0 || s.lower != nil {
+			spans = append(spans, span{lower: lower, upper: s.lower})
+		}
+		lower = s.upper
+	}
+	if len(a) == 0 || lower != nil {
+		spans = append(spans, span{lower: lower})
+	}
+	return normalize(spans, floor)
+}
+
+func spansString(spans []span) string {
+	s := make([]string, 0, len(spans))
+	for i := range spans {
+		s = append(s, spans[i].String())
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,361 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestRangeCheck(t *testing.T) {
+	tests := []struct {
+		rng     string
+		version string
+		want    bool
+	}{
+		{"[1.0.0,2.0.0)", "1.0.0", true},
+		{"[1.0.0,2.0.0)", "1.9.9", true},
+		{"[1.0.0,2.0.0)", "2.0.0", false},
+		{"[1.0.0,2.0.0)", "2.0.0-rc.1", true},
+		{"[1.0.0,2.0.0)", "1.0.0-rc.1", false},
+		{"(1.0.0,2.0.0]", "1.0.0", false},
+		{"(1.0.0,2.0.0]", "1.0.0+build", false},
+		{"(1.0.0,2.0.0]", "2.0.0", true},
+		{"(,1.5.0]", "0.0.1", true},
+		{"(,1.5.0]", "1.5.0", true},
+		{"(,1.5.0]", "1.5.1", false},
+		{"(1.5.0,)", "1.5.0", false},
+		{"(1.5.0,)", "100.0.0", true},
+		{"(,)", "1.2.3-alpha", true},
+		{"[1.2.3]", "1.2.3", true},
+		{"[1.2.3]", "1.2.3+build.1", true},
+		{"[1.2.3]", "1.2.4", false},
+		{"(,1.0.0],[1.2.0,)", "1.1.0", false},
+		{"(,1.0.0],[1.2.0,)", "1.0.0", true},
+		{"(,1.0.0], [1.2.0,)", "1.2.0", true},
+		{"[ 1.0.0 , 2.0.0 )", "1.5.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s in %s", tt.version, tt.rng), func(t *testing.T) {
+			r, err := semver.ParseRange(tt.rng)
+			if err != nil {
+				t.Fatalf("range '%s' should be valid but got '%s' instead", tt.rng, err)
+			}
+			v := semver.MustParse(tt.version)
+			if got := r.Check(&v); got != tt.want {
+				t.Errorf("Range(%s).Check(%s) = %v, want %v", tt.rng, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"1.0.0",
+		"[1.0.0",
+		"[1.0,2.0]",
+		"[,1.0.0]",
+		"(1.0.0,]",
+		"(1.2.3)",
+		"[1.2.3)",
+		"[]",
+		"[2.0.0,1.0.0]",
+		"[1.0.0,2.0.0) [3.0.0,)",
+		"[1.0.0,2.0.0),",
+		"[1.0.0,2.0.0,3.0.0]",
+	}
+	for _, rng := range invalid {
+		t.Run(rng, func(t *testing.T) {
+			_, err := semver.ParseRange(rng)
+			if err == nil {
+				t.Fatalf("range '%s' should be invalid", rng)
+			}
+		})
+	}
+}
+
+func TestRangeEqualBounds(t *testing.T) {
+	for _, rng := range []string{"(1.0.0,1.0.0)", "[1.0.0,1.0.0)", "(1.0.0,1.0.0]", "[1.0.0, 1.0.0+build)"} {
+		t.Run(rng, func(t *testing.T) {
+			if _, err := semver.ParseRange(rng); !errors.Is(err, semver.ErrInvalidRange) {
+				t.Errorf("ParseRange(%s) error = %v, want %v", rng, err, semver.ErrInvalidRange)
+			}
+		})
+	}
+	r, err := semver.ParseRange("[1.0.0,1.0.0]")
+	if err != nil {
+		t.Fatalf("ParseRange([1.0.0,1.0.0]) returned unexpected error: %v", err)
+	}
+	if got := r.String(); got != "[1.0.0]" {
+		t.Errorf("ParseRange([1.0.0,1.0.0]).String() = %s, want [1.0.0]", got)
+	}
+}
+
+func ExampleParseRange() {
+	r, _ := semver.ParseRange("[1.0.0,2.0.0),[3.0.0]")
+	for _, s := range []string{"1.5.0", "2.0.0", "3.0.0"} {
+		v := semver.MustParse(s)
+		fmt.Printf("%s: %v\n", s, r.Check(&v))
+	}
+	// Output:
+	// 1.5.0: true
+	// 2.0.0: false
+	// 3.0.0: true
+}
+
+func TestRangeString(t *testing.T) {
+	tests := []struct {
+		rng  string
+		want string
+	}{
+		{"[1.0.0,2.0.0)", "[1.0.0,2.0.0)"},
+		{"(1.0.0,2.0.0]", "(1.0.0,2.0.0]"},
+		{"(,1.5.0]", "(,1.5.0]"},
+		{"(1.5.0,)", "(1.5.0,)"},
+		{"[1.2.3]", "[1.2.3]"},
+		{"[1.2.3+build.1]", "[1.2.3]"},
+		{"(,)", "(,)"},
+		{"[0.0.0-0,)", "(,)"},
+		{"(,0.0.0-0)", "(,0.0.0-0)"},
+		{"(1.0.0,1.0.1-0)", "(,0.0.0-0)"},
+		{"(1.0.0-alpha,1.0.0-alpha.0)", "(,0.0.0-0)"},
+		{"[1.0.0-alpha,1.0.0-alpha.0)", "[1.0.0-alpha]"},
+		{"[1.0.1-0,2.0.0)", "(1.0.0,2.0.0)"},
+		{"[3.0.0,4.0.0),[1.0.0,2.0.0)", "[1.0.0,2.0.0),[3.0.0,4.0.0)"},
+		{"[1.0.0,2.0.0),[1.5.0,3.0.0)", "[1.0.0,3.0.0)"},
+		{"[1.0.0,2.0.0),[2.0.0,3.0.0)", "[1.0.0,3.0.0)"},
+		{"[1.0.0,2.0.0],(2.0.0,3.0.0)", "[1.0.0,3.0.0)"},
+		{"[1.0.0,2.0.0),(2.0.0,3.0.0)", "[1.0.0,2.0.0),(2.0.0,3.0.0)"},
+		{"[0.0.0]", "[0.0.0]"},
+		{"[0.0.0,1.0.0)", "[0.0.0,1.0.0)"},
+		{"[1.0.0],(1.0.1-rc,2.0.0)", "[1.0.0],(1.0.1-rc,2.0.0)"},
+		{"[1.0.0],[1.0.1],[1.0.2]", "[1.0.0],[1.0.1],[1.0.2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rng, func(t *testing.T) {
+			r := semver.MustParseRange(tt.rng)
+			if got := r.String(); got != tt.want {
+				t.Errorf("Range(%s).String() = %s, want %s", tt.rng, got, tt.want)
+			}
+			again := semver.MustParseRange(r.String())
+			if again.String() != r.String() {
+				t.Errorf("Range(%s) is not stable: %s != %s", tt.rng, again.String(), r.String())
+			}
+		})
+	}
+}
+
+func randomBound(r *rand.Rand) string {
+	versions := []string{"0.0.0-0", "0.0.0", "0.0.1-0", "0.0.1", "1.0.0-0", "1.0.0-rc", "1.0.0-rc.0", "1.0.0",
+		"1.0.1-0", "1.0.1-rc", "1.0.1", "1.0.2-0", "1.0.2", "1.0.3-0", "2.0.0-0", "2.0.0"}
+	return versions[r.Intn(len(versions))]
+}
+
+func randomInterval(r *rand.Rand) string {
+	if r.Intn(5) == 0 {
+		return "[" + randomBound(r) + "]"
+	}
+	var b strings.Builder
+	lower, upper := "", ""
+	if r.Intn(4) > 0 {
+		lower = randomBound(r)
+	}
+	if r.Intn(4) > 0 {
+		upper = randomBound(r)
+	}
+	if lower != "" && r.Intn(2) == 0 {
+		b.WriteString("[")
+	} else {
+		b.WriteString("(")
+	}
+	b.WriteString(lower + "," + upper)
+	if upper != "" && r.Intn(2) == 0 {
+		b.WriteString("]")
+	} else {
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+func TestRangeStringRoundTrip(t *testing.T) {
+	versions := []string{"0.0.0-0", "0.0.0-rc", "0.0.0", "0.0.1-0", "0.0.1", "0.5.0", "1.0.0-0", "1.0.0-0.0", "1.0.0-rc",
+		"1.0.0-rc.0", "1.0.0-rc.1", "1.0.0", "1.0.1-0", "1.0.1-rc", "1.0.1-rc.0", "1.0.1", "1.0.2-0", "1.0.2", "1.5.0-rc",
+		"1.5.0", "2.0.0-0", "2.0.0", "2.0.1-0", "3.0.0"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		intervals := make([]string, 1+r.Intn(3))
+		for j := range intervals {
+			intervals[j] = randomInterval(r)
+		}
+		rng, err := semver.ParseRange(strings.Join(intervals, ","))
+		if err != nil {
+			continue
+		}
+		again, err := semver.ParseRange(rng.String())
+		if err != nil {
+			t.Fatalf("Range(%s).String() = %s can not be parsed: %v", strings.Join(intervals, ","), rng.String(), err)
+		}
+		if again.String() != rng.String() {
+			t.Errorf("Range(%s) is not stable: %s != %s", strings.Join(intervals, ","), again.String(), rng.String())
+		}
+		for _, s := range versions {
+			v := semver.MustParse(s)
+			if rng.Check(&v) != again.Check(&v) {
+				t.Errorf("Range(%s) and Range(%s) differ on %s", strings.Join(intervals, ","), rng.String(), s)
+			}
+		}
+	}
+}
+
+func TestRangeAlgebra(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       string
+		union      string
+		intersect  string
+		complement string
+		subset     bool
+	}{
+		{"disjoint",
+			"[1.0.0,2.0.0)", "[3.0.0,4.0.0)",
+			"[1.0.0,2.0.0),[3.0.0,4.0.0)", "(,0.0.0-0)", "(,1.0.0),[2.0.0,)", false,
+		},
+		{"overlapping",
+			"[1.0.0,2.0.0)", "[1.5.0,3.0.0]",
+			"[1.0.0,3.0.0]", "[1.5.0,2.0.0)", "(,1.0.0),[2.0.0,)", false,
+		},
+		{"contained",
+			"[1.2.0,1.3.0)", "[1.0.0,2.0.0)",
+			"[1.0.0,2.0.0)", "[1.2.0,1.3.0)", "(,1.2.0),[1.3.0,)", true,
+		},
+		{"touching at prerelease boundary",
+			"(,1.0.0-rc.1]", "(1.0.0-rc.1,)",
+			"(,)", "(,0.0.0-0)", "(1.0.0-rc.1,)", false,
+		},
+		{"single versions",
+			"[1.0.0]", "[1.0.0],[2.0.0]",
+			"[1.0.0],[2.0.0]", "[1.0.0]", "(,1.0.0),(1.0.0,)", true,
+		},
+		{"everything",
+			"(,)", "[1.0.0,)",
+			"(,)", "[1.0.0,)", "(,0.0.0-0)", false,
+		},
+		{"nothing",
+			"(,0.0.0-0)", "[1.0.0,)",
+			"[1.0.0,)", "(,0.0.0-0)", "(,)", true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := semver.MustParseRange(tt.a)
+			b := semver.MustParseRange(tt.b)
+			if got := a.Union(&b); got.String() != tt.union {
+				t.Errorf("%s ∪ %s = %s, want %s", tt.a, tt.b, got.String(), tt.union)
+			}
+			if got := a.Intersect(&b); got.String() != tt.intersect {
+				t.Errorf("%s ∩ %s = %s, want %s", tt.a, tt.b, got.String(), tt.intersect)
+			}
+			if got := a.Complement(); got.String() != tt.complement {
+				t.Errorf("complement of %s = %s, want %s", tt.a, got.String(), tt.complement)
+			}
+			if got := a.IsSubsetOf(&b); got != tt.subset {
+				t.Errorf("%s ⊂ %s = %v, want %v", tt.a, tt.b, got, tt.subset)
+			}
+			if got := a.Intersect(&b); got.IsEmpty() != (tt.intersect == "(,0.0.0-0)") {
+				t.Errorf("%s ∩ %s is empty = %v", tt.a, tt.b, got.IsEmpty())
+			}
+		})
+	}
+}
+
+func TestConstraintRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"*", "releases (,)"},
+		{"1.2.3", "[1.2.3]"},
+		{"1.2.3-rc.1", "[1.2.3-rc.1]"},
+		{"^1.2.3", "releases [1.2.3,2.0.0)"},
+		{"~1.2 || ^1.2.5", "releases [1.2.0,2.0.0)"},
+		{">1.2.3 <=2.0.0", "releases [1.2.4,2.0.0]"},
+		{"<1.0.0 || >=2.0.0", "releases (,1.0.0),[2.0.0,)"},
+		{">=1.2.3-rc.1 <2.0.0", "releases [1.2.3,2.0.0) prereleases [1.2.3-rc.1,1.2.3)"},
+		{">=1.2.3-rc.1 <=1.2.3", "[1.2.3-rc.1,1.2.3]"},
+		{">2.0.0 <1.0.0", "(,0.0.0-0)"},
+		{"<*", "(,0.0.0-0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c := semver.MustParseConstraint(tt.constraint)
+			r := c.Range()
+			if got := r.String(); got != tt.want {
+				t.Errorf("Constraint(%s).Range() = %s, want %s", tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintRangeMatchesCheck(t *testing.T) {
+	constraints := []string{
+		"*", "^1.2.0", "~1.4", "=1.5.0-rc.1", ">=1.2.3-rc.1 <2.0.0", ">1.2.3-alpha <1.2.3-beta || ^2.0.0-0",
+		"1.2.3 - 1.5.0-rc.2", "<1.0.0 || >=2.0.0", ">=0.0.0-0", "1.x || >=3.0.0-rc.1",
+	}
+	versions := []string{
+		"0.0.0-0", "0.0.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.2.3-alpha", "1.2.3-alpha.1", "1.2.3-beta",
+		"1.2.3-rc.1", "1.2.3-rc.2", "1.2.3", "1.4.0-rc.1", "1.4.5", "1.5.0-rc.1", "1.5.0-rc.2", "1.5.0",
+		"1.9.9", "2.0.0-0", "2.0.0-rc.1", "2.0.0", "2.5.0-beta", "3.0.0-rc.1", "3.0.0-rc.2", "3.0.0", "4.0.0-rc.1",
+	}
+	ranges := make([]semver.Range, 0, len(constraints))
+	for _, s := range constraints {
+		c := semver.MustParseConstraint(s)
+		r := c.Range()
+		ranges = append(ranges, r)
+		for _, vs := range versions {
+			v := semver.MustParse(vs)
+			if got, want := r.Check(&v), c.Check(&v); got != want {
+				t.Errorf("Constraint(%s).Range().Check(%s) = %v, Check() = %v", s, vs, got, want)
+			}
+		}
+	}
+	for i := range ranges {
+		for j := range ranges {
+			ci := semver.MustParseConstraint(constraints[i])
+			cj := semver.MustParseConstraint(constraints[j])
+			intersection := ranges[i].Intersect(&ranges[j])
+			for _, vs := range versions {
+				v := semver.MustParse(vs)
+				if got, want := intersection.Check(&v), ci.Check(&v) && cj.Check(&v); got != want {
+					t.Errorf("(%s ∩ %s).Check(%s) = %v, want %v", constraints[i], constraints[j], vs, got, want)
+				}
+				if ranges[i].IsSubsetOf(&ranges[j]) && ci.Check(&v) && !cj.Check(&v) {
+					t.Errorf("%s ⊂ %s but %s satisfies only the first one", constraints[i], constraints[j], vs)
+				}
+			}
+		}
+	}
+
+	caret := semver.MustParseConstraint("^1.2.0")
+	exact := semver.MustParseConstraint("=1.5.0-rc.1")
+	cr, er := caret.Range(), exact.Range()
+	if i := cr.Intersect(&er); !i.IsEmpty() {
+		t.Errorf("^1.2.0 ∩ =1.5.0-rc.1 = %s, want empty range", i.String())
+	}
+	if er.IsSubsetOf(&cr) {
+		t.Errorf("=1.5.0-rc.1 should not be subset of ^1.2.0")
+	}
+}
+
+func ExampleRange_IsSubsetOf() {
+	caret := semver.MustParseConstraint("^1.2.0")
+	tilde := semver.MustParseConstraint("~1.4")
+	cr, tr := caret.Range(), tilde.Range()
+	i := cr.Intersect(&tr)
+	fmt.Println(tr.IsSubsetOf(&cr), cr.IsSubsetOf(&tr), i.String())
+	// Output:
+	// true false releases [1.4.0,1.5.0)
+}
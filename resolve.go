@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Checker decides whether version satisfies some requirement. It is implemented by both Constraint and Range.
+type Checker interface {
+	Check(v *Version) bool
+}
+
+// ResolveOption is function option that customizes the way Resolve picks version
+type ResolveOption func(*resolver)
+
+type prereleasePolicy int
+
+const (
+	prereleaseDefault prereleasePolicy = iota
+	prereleaseInclude
+	prereleaseExclude
+)
+
+type resolver struct {
+	lowest        bool
+	prerelease    prereleasePolicy
+	preferNoBuild bool
+}
+
+// Lowest makes Resolve return the lowest satisfying version instead of the highest one
+func Lowest() ResolveOption {
+	return func(r *resolver) {
+		r.lowest = true
+	}
+}
+
+// IncludePrerelease makes Resolve consider all prerelease versions that are within constraint according to
+// precedence rules, lifting the rule that allows only prereleases of the very same major.minor.patch tuple
+// as one of the constraint comparators. It is supported by Constraint and ConstraintFlag only; Range does
+// not know bounds of the constraint it was created from, so Resolve fails with ErrUnsupportedChecker.
+func IncludePrerelease() ResolveOption {
+	return func(r *resolver) {
+		r.prerelease = prereleaseInclude
+	}
+}
+
+// ExcludePrerelease makes Resolve skip all prerelease versions, even if they satisfy the constraint
+func ExcludePrerelease() ResolveOption {
+	return func(r *resolver) {
+		r.prerelease = prereleaseExclude
+	}
+}
+
+// PreferNoBuildMetadata makes Resolve choose version without build metadata if there are few candidates of
+// equal precedence. By default first of them found on the list is returned.
+func PreferNoBuildMetadata() ResolveOption {
+	return func(r *resolver) {
+		r.preferNoBuild = true
+	}
+}
+
+// ErrNoMatch is returned by Resolve if none of candidates satisfies the constraint
+var ErrNoMatch = errors.New("no version satisfies constraint")
+
+// ErrUnsupportedChecker is returned by Resolve if checker does not support some of given options
+var ErrUnsupportedChecker = errors.New("option is not supported by checker")
+
+// prereleaseChecker is implemented by checkers able to lift their rule on prereleases, see IncludePrerelease
+type prereleaseChecker interface {
+	checkIncludingPrerelease(v *Version) bool
+}
+
+// Resolve returns the highest of candidate versions that satisfies the constraint. Behavior may be customized
+// with options, e.g. to choose the lowest version instead. If there is no such version ErrNoMatch is returned.
+func Resolve(candidates []Version, c Checker, options ...ResolveOption) (Version, error) {
+	r := resolver{}
+	for _, o := range options {
+		o(&r)
+	}
+	if _, ok := c.(prereleaseChecker); !ok && r.prerelease == prereleaseInclude {
+		return Version{}, fmt.Errorf("%w: %T can not include prereleases", ErrUnsupportedChecker, c)
+	}
+
+	var best *Version
+	for i := range candidates {
+		v := &candidates[i]
+		if !r.match(v, c) {
+			continue
+		}
+		if best == nil || r.better(v, best) {
+			best = v
+		}
+	}
+	if best == nil {
+		return Version{}, ErrNoMatch
+	}
+	return *best, nil
+}
+
+func (r *resolver) match(v *Version, c Checker) bool {
+	if len(v.Prerelease) > 0 && r.prerelease == prereleaseExclude {
+		return false
+	}
+	if r.prerelease == prereleaseInclude {
+		return c.(prereleaseChecker).checkIncludingPrerelease(v)
+	}
+	return c.Check(v)
+}
+
+// better returns true if v should be chosen over current best one
+func (r *resolver) better(v, best *Version) bool {
+	switch cmp := Compare(v, best); {
+	case cmp == 0:
+		return r.preferNoBuild && len(v.Buildmetadata) == 0 && len(best.Buildmetadata) > 0
+	case r.lowest:
+		return cmp < 0
+	default:
+		return cmp > 0
+	}
+}
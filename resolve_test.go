@@ -0,0 +1,87 @@
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestResolve(t *testing.T) {
+	candidates := []string{
+		"1.0.0", "1.2.0+build.1", "1.2.0", "1.3.0-rc.1", "1.3.0", "1.4.0-beta.2", "2.0.0-rc.1", "2.0.0", "2.1.0",
+	}
+	vs := make([]semver.Version, 0, len(candidates))
+	for _, c := range candidates {
+		vs = append(vs, semver.MustParse(c))
+	}
+
+	tests := []struct {
+		name       string
+		constraint string
+		options    []semver.ResolveOption
+		want       string
+		wantErr    error
+	}{
+		{"highest by default", "^1.0.0", nil, "1.3.0", nil},
+		{"lowest", "^1.0.0", []semver.ResolveOption{semver.Lowest()}, "1.0.0", nil},
+		{"prereleases not matching constraint are skipped", "<2.0.0", nil, "1.3.0", nil},
+		{"prereleases of the same tuple match", ">=2.0.0-rc.0 <2.0.0", nil, "2.0.0-rc.1", nil},
+		{"prereleases included", "^1.0.0", []semver.ResolveOption{semver.IncludePrerelease()}, "1.4.0-beta.2", nil},
+		{"prereleases excluded", ">=2.0.0-rc.0 <2.0.0", []semver.ResolveOption{semver.ExcludePrerelease()}, "", semver.ErrNoMatch},
+		{"first of equal versions", "~1.2", nil, "1.2.0+build.1", nil},
+		{"prefer no build metadata", "~1.2", []semver.ResolveOption{semver.PreferNoBuildMetadata()}, "1.2.0", nil},
+		{"no match", ">3", nil, "", semver.ErrNoMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := semver.MustParseConstraint(tt.constraint)
+			got, err := semver.Resolve(vs, &c, tt.options...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve(%s) returned error: %v, want %v", tt.constraint, err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Resolve(%s) = %s, want %s", tt.constraint, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIncludePrerelease(t *testing.T) {
+	vs := []semver.Version{semver.MustParse("1.0.0"), semver.MustParse("1.5.0-rc.1")}
+	c := semver.MustParseConstraint("^1.0.0")
+	var f semver.ConstraintFlag
+	if err := f.Set("^1.0.0"); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	for name, checker := range map[string]semver.Checker{"constraint": &c, "flag": &f} {
+		got, err := semver.Resolve(vs, checker, semver.IncludePrerelease())
+		if err != nil {
+			t.Fatalf("Resolve(%s) returned unexpected error: %v", name, err)
+		}
+		if got.String() != "1.5.0-rc.1" {
+			t.Errorf("Resolve(%s) = %s, want 1.5.0-rc.1", name, got.String())
+		}
+	}
+
+	r := c.Range()
+	if _, err := semver.Resolve(vs, &r, semver.IncludePrerelease()); !errors.Is(err, semver.ErrUnsupportedChecker) {
+		t.Errorf("Resolve(range) error = %v, want %v", err, semver.ErrUnsupportedChecker)
+	}
+	if got, err := semver.Resolve(vs, &r); err != nil || got.String() != "1.0.0" {
+		t.Errorf("Resolve(range) = %s, %v, want 1.0.0", got.String(), err)
+	}
+}
+
+func ExampleResolve() {
+	var vs []semver.Version
+	for _, v := range []string{"1.2.0", "1.9.3", "2.0.0-rc.1", "2.0.0", "2.3.1"} {
+		vs = append(vs, semver.MustParse(v))
+	}
+	r := semver.MustParseRange("[1.0.0,2.0.0]")
+	v, _ := semver.Resolve(vs, &r)
+	fmt.Println(v.String())
+	// Output:
+	// 2.0.0
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
 	"strings"
 )
 
@@ -28,7 +27,8 @@ func New(options ...func(*Version) error) (*Version, error) {
 	return &s, err
 }
 
-// Parse unpacks provided version string to predefined Version struct
+// Parse unpacks provided version string to predefined Version struct. If string is not a valid version
+// returned error is a *ParseError describing where and why parsing failed.
 func Parse(s string) (Version, error) {
 	v := Version{}
 	v.Prerelease = []string{}
@@ -36,7 +36,7 @@ func Parse(s string) (Version, error) {
 
 	_, err := defaultParser(0, s, &v)
 	if err != nil {
-		return Version{}, err
+		return Version{}, withInput(err, s)
 	}
 
 	return v, nil
@@ -60,15 +60,15 @@ func SetCore(core string) func(*Version) error {
 		}
 		parser := sequence(
 			major(),
-			dot(),
+			dot(ComponentMinor),
 			minor(),
-			dot(),
+			dot(ComponentPatch),
 			patch(),
 			excess(),
 		)
 
 		_, err := parser(0, core, s)
-		return err
+		return withInput(err, core)
 	}
 }
 
@@ -89,9 +89,9 @@ func Prerelease(pr string) func(*Version) error {
 		if pr == "" {
 			return nil
 		}
-		parser := sequence(prerelease(), repeat(dot(), prerelease()), excess())
+		parser := sequence(prerelease(), repeat(dot(ComponentPrerelease), prerelease()), excess())
 		_, err := parser(0, pr, s)
-		return err
+		return withInput(err, pr)
 	}
 }
 
@@ -105,9 +105,9 @@ func BuildMetadata(bl string) func(*Version) error {
 		if bl == "" {
 			return nil
 		}
-		parser := sequence(buildmetadata(), repeat(dot(), buildmetadata()), excess())
+		parser := sequence(buildmetadata(), repeat(dot(ComponentBuildmetadata), buildmetadata()), excess())
 		_, err := parser(0, bl, s)
-		return err
+		return withInput(err, bl)
 	}
 }
 
@@ -181,26 +181,145 @@ func NextRelease() BumpOption {
 	}
 }
 
-// NextPrerelease is incrementing last numeric prerelease component
-func NextPrerelease() BumpOption {
+// ErrNoNumericPrerelease is returned by NextPrerelease if prerelease has no numeric identifier to increment
+var ErrNoNumericPrerelease = errors.New("no numeric identifier in prerelease")
+
+// PrereleaseOption is function option that changes behaviour of NextPrerelease
+type PrereleaseOption func(*prereleaseBump)
+
+type prereleaseBump struct {
+	counter string
+}
+
+// AppendCounter makes NextPrerelease append numeric identifier of given value to prerelease that has none
+// instead of failing: `1.0.0-alpha` -> `1.0.0-alpha.1` for AppendCounter(1)
+func AppendCounter(start uint64) PrereleaseOption {
+	return func(p *prereleaseBump) {
+		p.counter = fromUint64(start)
+	}
+}
+
+// NextPrerelease is incrementing last numeric prerelease component: `1.0.0-rc.1` -> `1.0.0-rc.2`. If there
+// is no numeric component ErrNoNumericPrerelease is returned unless AppendCounter option is used.
+func NextPrerelease(options ...PrereleaseOption) BumpOption {
+	p := prereleaseBump{}
+	for _, o := range options {
+		o(&p)
+	}
 	return func(v *Version) error {
 		if len(v.Prerelease) == 0 {
 			return ErrNoPrerelease
 		}
-		for i := len(v.Prerelease) - 1; i >= 0; i-- {
-			const baseDec = 10
-			bigN, ok := big.NewInt(0).SetString(v.Prerelease[i], baseDec)
-			one := big.NewInt(1)
-			if ok {
-				bigN.Add(bigN, one)
-				v.Prerelease[i] = bigN.String()
-				break
+		prerelease, found, err := incrementLast(v.Prerelease)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if p.counter == "" {
+				return fmt.Errorf("%w: %s", ErrNoNumericPrerelease, strings.Join(v.Prerelease, "."))
+			}
+			prerelease = append(prerelease, p.counter)
+		}
+		v.Prerelease = prerelease
+		return nil
+	}
+}
+
+// ErrNotGreater is returned by EnsureGreater if bumped version is not greater than the original one
+var ErrNotGreater = errors.New("bumped version is not greater than original one")
+
+// errEnsureGreater is returned by option created with EnsureGreater to make Bump check the bumped version
+var errEnsureGreater = errors.New("EnsureGreater option must be applied with Bump")
+
+// EnsureGreater returns option that applies given options and makes Bump fail with ErrNotGreater if bumped version
+// does not have strictly higher precedence than the version Bump was called on. Check is done once all options
+// of Bump are applied so EnsureGreater with no options of its own may be given in any place, e.g. as the last one:
+//
+//	v.Bump(semver.EnsureGreater(semver.NextPrerelease(), semver.Prerelease("hotfix")))
+//	v.Bump(semver.NextPatch(), semver.EnsureGreater())
+func EnsureGreater(options ...BumpOption) BumpOption {
+	return func(v *Version) error {
+		for _, o := range options {
+			if err := o(v); err != nil && !errors.Is(err, errEnsureGreater) {
+				return err
+			}
+		}
+		return errEnsureGreater
+	}
+}
+
+// PreMajor bumps to prerelease of next major version the way `npm version premajor --preid <id>` does it:
+// `1.2.3` -> `2.0.0-rc.0`. Empty id gives bare numeric prerelease: `2.0.0-0`.
+func PreMajor(id string) BumpOption {
+	return preBump(id, NextMajor())
+}
+
+// PreMinor bumps to prerelease of next minor version the way `npm version preminor --preid <id>` does it:
+// `1.2.3` -> `1.3.0-rc.0`. Empty id gives bare numeric prerelease: `1.3.0-0`.
+func PreMinor(id string) BumpOption {
+	return preBump(id, NextMinor())
+}
+
+// PrePatch bumps to prerelease of next patch version the way `npm version prepatch --preid <id>` does it:
+// `1.2.3` -> `1.2.4-rc.0`. Empty id gives bare numeric prerelease: `1.2.4-0`.
+func PrePatch(id string) BumpOption {
+	return preBump(id, NextPatch())
+}
+
+// PreRelease bumps to next prerelease the way `npm version prerelease --preid <id>` does it. Release version
+// is bumped like with PrePatch: `1.2.3` -> `1.2.4-rc.0`. For prerelease version the last numeric identifier is
+// incremented: `1.2.3-rc.0` -> `1.2.3-rc.1`, or `.0` is appended if there is none: `1.2.3-rc` -> `1.2.3-rc.0`.
+// If version is on different prerelease than id it is replaced: `1.2.3-alpha.3` -> `1.2.3-rc.0`.
+func PreRelease(id string) BumpOption {
+	return func(v *Version) error {
+		if len(v.Prerelease) == 0 {
+			return PrePatch(id)(v)
+		}
+		return preBump(id)(v)
+	}
+}
+
+// preBump applies options and then npm `pre` increment of prerelease
+func preBump(id string, options ...BumpOption) BumpOption {
+	return func(v *Version) error {
+		if id != "" {
+			if _, err := parseIdentifiers([]string{id}, ComponentPrerelease, Prerelease); err != nil {
+				return err
+			}
+		}
+		for _, o := range options {
+			if err := o(v); err != nil {
+				return err
 			}
 		}
+		prerelease, found, err := incrementLast(v.Prerelease)
+		if err != nil {
+			return err
+		}
+		if !found {
+			prerelease = append(prerelease, "0")
+		}
+		if id != "" && (prerelease[0] != id || len(prerelease) < 2 || !isNum(prerelease[1])) {
+			prerelease = []string{id, "0"}
+		}
+		v.Prerelease = prerelease
 		return nil
 	}
 }
 
+// incrementLast returns copy of identifiers with the last numeric one incremented; found is false if there is
+// no numeric identifier and copy is returned unchanged
+func incrementLast(ids []string) (incremented []string, found bool, err error) {
+	incremented = append([]string{}, ids...)
+	for i := len(incremented) - 1; i >= 0; i-- {
+		if isNum(incremented[i]) {
+			incremented[i], err = increment(incremented[i])
+			return incremented, true, err
+		}
+	}
+	return incremented, false, nil
+}
+
 // Bump changes version to newer using provided list of bump options
 func (semver *Version) Bump(options ...BumpOption) (Version, error) {
 	if len(options) == 0 {
@@ -213,13 +332,20 @@ func (semver *Version) Bump(options ...BumpOption) (Version, error) {
 	newSemver := *semver
 	newSemver.Buildmetadata = []string{}
 
-	var err error
+	ensureGreater := false
 	for _, option := range options {
-		err = option(&newSemver)
+		err := option(&newSemver)
+		if errors.Is(err, errEnsureGreater) {
+			ensureGreater = true
+			continue
+		}
 		if err != nil {
 			return Version{}, err
 		}
 	}
+	if ensureGreater && !Less(semver, &newSemver) {
+		return Version{}, fmt.Errorf("%w: %s -> %s", ErrNotGreater, semver.String(), newSemver.String())
+	}
 	return newSemver, nil
 }
 
@@ -245,28 +371,111 @@ func (semver *Version) Valid() bool {
 	return err == nil
 }
 
-// Less perform comparison of to specified versions. It strictly follows rules
-// of semver specification, paragraph 11.: https://semver.org/#spec-item-11
-func Less(s1, s2 *Version) bool {
+// Compare returns -1, 0 or 1 depending on whether v1 has lower, equal or higher precedence than v2.
+// It strictly follows rules of semver specification, paragraph 11.: https://semver.org/#spec-item-11
+func Compare(v1, v2 *Version) int {
 	// 1.0.0 < 2.0.0
-	if less, eq := lessOrEqual(s1.Major, s2.Major); !eq {
-		return less
+	if less, eq := lessOrEqual(v1.Major, v2.Major); !eq {
+		return order(less)
 	}
 	// 0.1.0 < 0.2.0
-	if less, eq := lessOrEqual(s1.Minor, s2.Minor); !eq {
-		return less
+	if less, eq := lessOrEqual(v1.Minor, v2.Minor); !eq {
+		return order(less)
 	}
 	// 0.0.1 < 0.0.2
-	if less, eq := lessOrEqual(s1.Patch, s2.Patch); !eq {
-		return less
+	if less, eq := lessOrEqual(v1.Patch, v2.Patch); !eq {
+		return order(less)
 	}
 	// 1.0.0-alpha.1 < 1.0.0
 	// 1.0.0-alpha < 1.0.0-alpha.1
-	if less, eq := lessOrEqualStrings(s1.Prerelease, s2.Prerelease); !eq {
-		return less
+	if less, eq := lessOrEqualStrings(v1.Prerelease, v2.Prerelease); !eq {
+		return order(less)
+	}
+	// note: at this point both versions are equal
+	return 0
+}
+
+func order(less bool) int {
+	if less {
+		return -1
 	}
-	// note: at this point false means both versions are equal
-	return false
+	return 1
+}
+
+// Less perform comparison of to specified versions. It strictly follows rules
+// of semver specification, paragraph 11.: https://semver.org/#spec-item-11
+func Less(s1, s2 *Version) bool {
+	return Compare(s1, s2) < 0
+}
+
+// LessOrEqual returns true if v1 has lower or equal precedence than v2
+func LessOrEqual(v1, v2 *Version) bool {
+	return Compare(v1, v2) <= 0
+}
+
+// Greater returns true if v1 has higher precedence than v2
+func Greater(v1, v2 *Version) bool {
+	return Compare(v1, v2) > 0
+}
+
+// GreaterOrEqual returns true if v1 has higher or equal precedence than v2
+func GreaterOrEqual(v1, v2 *Version) bool {
+	return Compare(v1, v2) >= 0
+}
+
+// Equal returns true if both versions have the same precedence. Note that build metadata is ignored
+// so `1.0.0+a` is equal to `1.0.0+b`.
+func Equal(v1, v2 *Version) bool {
+	return Compare(v1, v2) == 0
+}
+
+// CompareWithBuild works like Compare but if both versions have equal precedence their build metadata is
+// compared as well: version without build metadata goes first, otherwise identifiers are compared one by one
+// the same way as prerelease ones. Semver spec does not define such order but it is total, meaning only
+// identical versions are equal, so it may be used to sort versions in a reproducible way.
+func CompareWithBuild(v1, v2 *Version) int {
+	if cmp := Compare(v1, v2); cmp != 0 {
+		return cmp
+	}
+	if less, eq := lessOrEqualIdentifiers(v1.Buildmetadata, v2.Buildmetadata); !eq {
+		return order(less)
+	}
+	return 0
+}
+
+// LessWithBuild returns true if v1 is lower than v2 according to order defined by CompareWithBuild
+func LessWithBuild(v1, v2 *Version) bool {
+	return CompareWithBuild(v1, v2) < 0
+}
+
+// Compare is a method variant of Compare function
+func (semver *Version) Compare(o *Version) int {
+	return Compare(semver, o)
+}
+
+// Less is a method variant of Less function
+func (semver *Version) Less(o *Version) bool {
+	return Less(semver, o)
+}
+
+// LessOrEqual is a method variant of LessOrEqual function
+func (semver *Version) LessOrEqual(o *Version) bool {
+	return LessOrEqual(semver, o)
+}
+
+// Greater is a method variant of Greater function
+func (semver *Version) Greater(o *Version) bool {
+	return Greater(semver, o)
+}
+
+// GreaterOrEqual is a method variant of GreaterOrEqual function
+func (semver *Version) GreaterOrEqual(o *Version) bool {
+	return GreaterOrEqual(semver, o)
+}
+
+// Equal is a method variant of Equal function
+func (semver *Version) Equal(o *Version) bool {
+	return Equal(semver, o)
 }
 
 func lessOrEqual(a, b string) (less, eq bool) {
@@ -289,24 +498,25 @@ func lessOrEqualStrings(a, b []string) (less, eq bool) {
 	if len(b) == 0 {
 		return true, false
 	}
+	return lessOrEqualIdentifiers(a, b)
+}
+
+// lessOrEqualNumbers compares numeric identifiers by value. Leading zeros are forbidden in prerelease but allowed
+// in build metadata, so if values are equal the one with less leading zeros goes first.
+func lessOrEqualNumbers(a, b string) (less, eq bool) {
+	if less, eq := lessOrEqual(strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")); !eq {
+		return less, false
+	}
+	return lessOrEqual(a, b)
+}
+
+// lessOrEqualIdentifiers compares dot separated identifiers one by one, numeric ones as numbers, other ones
+// lexically in ASCII order; if all of them are equal shorter list is the lower one
+func lessOrEqualIdentifiers(a, b []string) (less, eq bool) {
 	n := min(len(a), len(b))
 	for i := 0; i < n; i++ {
-		aIsNum, aVal := isNum(a[i])
-		bIsNum, bVal := isNum(b[i])
-		if aIsNum && !bIsNum {
-			return true, false
-		}
-		if !aIsNum && bIsNum {
-			return false, false
-		}
-		if aIsNum && bIsNum {
-			return aVal < bVal, aVal == bVal
-		}
-		if a[i] < b[i] {
-			return true, false
-		}
-		if a[i] > b[i] {
-			return false, false
+		if less, eq := lessOrEqualIdentifier(a[i], b[i]); !eq {
+			return less, false
 		}
 	}
 	if len(a) < len(b) {
@@ -318,9 +528,32 @@ func lessOrEqualStrings(a, b []string) (less, eq bool) {
 	return false, true
 }
 
-func isNum(s string) (num bool, val int) {
-	val, err := strconv.Atoi(s)
-	return err == nil, val
+// lessOrEqualIdentifier compares single identifiers: numeric ones as numbers and always lower than
+// alphanumeric ones, which are compared lexically in ASCII order
+func lessOrEqualIdentifier(a, b string) (less, eq bool) {
+	aIsNum := isNum(a)
+	bIsNum := isNum(b)
+	switch {
+	case aIsNum && bIsNum:
+		return lessOrEqualNumbers(a, b)
+	case aIsNum != bIsNum:
+		return aIsNum, false
+	}
+	return a < b, a == b
+}
+
+// isNum returns true if identifier consists of digits only. Numbers of any size are accepted and
+// compared with lessOrEqual as there is no limit of their length in semver spec.
+func isNum(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 func min(a, b int) int {
@@ -346,60 +579,43 @@ var defaultParser = semverParser()
 
 type consumer func(pos int, stream string, v *Version) (remain string, err error)
 
-type positionError struct {
-	pos int
-	msg string
-}
-
-func positionErr(pos int, format string, a ...interface{}) error {
-	return &positionError{
-		pos: pos,
-		msg: fmt.Sprintf(format, a...),
-	}
-}
-
-// Error returns error with stream position where error has occurred
-func (e *positionError) Error() string {
-	return fmt.Sprintf("error at position %d: %s", e.pos, e.msg)
-}
-
 func semverParser() consumer {
 	var parser = []consumer{
 		major(),
-		dot(),
+		dot(ComponentMinor),
 		minor(),
-		dot(),
+		dot(ComponentPatch),
 		patch(),
-		optional(minus(), prerelease(), repeat(dot(), prerelease())),
+		optional(minus(), prerelease(), repeat(dot(ComponentPrerelease), prerelease())),
 
-		optional(plus(), buildmetadata(), repeat(dot(), buildmetadata())),
+		optional(plus(), buildmetadata(), repeat(dot(ComponentBuildmetadata), buildmetadata())),
 		excess(),
 	}
 	return sequence(parser...)
 }
 
-func literal(val string) consumer {
+func literal(val string, c Component) consumer {
 	return func(pos int, stream string, v *Version) (remain string, err error) {
 		if stream == "" {
-			return stream, positionErr(pos, "unexpected end of stream while %s was expected", nameFor(val))
+			return stream, positionErr(pos, c, ErrUnexpectedEnd, "unexpected end of stream while %s was expected", nameFor(val))
 		}
 		if strings.HasPrefix(stream, val) {
 			return stream[len(val):], nil
 		}
-		return stream, positionErr(pos, "unexpected character in place where %s was expected", nameFor(val))
+		return stream, positionErr(pos, c, ErrInvalidCharacter, "unexpected character in place where %s was expected", nameFor(val))
 	}
 }
 
-func dot() consumer {
-	return literal(".")
+func dot(c Component) consumer {
+	return literal(".", c)
 }
 
 func plus() consumer {
-	return literal("+")
+	return literal("+", ComponentBuildmetadata)
 }
 
 func minus() consumer {
-	return literal("-")
+	return literal("-", ComponentPrerelease)
 }
 
 func nameFor(val string) string {
@@ -415,7 +631,7 @@ func nameFor(val string) string {
 	}
 }
 
-func number(f func(v *Version, num string)) consumer {
+func number(c Component, f func(v *Version, num string)) consumer {
 	return func(pos int, stream string, v *Version) (remain string, err error) {
 		var num string
 		for i, s := range stream {
@@ -425,11 +641,14 @@ func number(f func(v *Version, num string)) consumer {
 			num = stream[0 : i+1]
 			remain = stream[i+1:]
 		}
+		if stream == "" {
+			return stream, positionErr(pos, c, ErrUnexpectedEnd, "unexpected end of stream while %s number was expected", c)
+		}
 		if num == "" {
-			return stream, positionErr(pos+1, "unexpected non-numeric character")
+			return stream, positionErr(pos, c, ErrInvalidCharacter, "unexpected non-numeric character")
 		}
 		if len(num) > 1 && num[0] == '0' {
-			return stream, positionErr(pos+1, "unexpected leading zero")
+			return stream, positionErr(pos, c, ErrLeadingZero, "unexpected leading zero")
 		}
 
 		f(v, num)
@@ -439,15 +658,15 @@ func number(f func(v *Version, num string)) consumer {
 }
 
 func major() consumer {
-	return number(func(v *Version, n string) { v.Major = n })
+	return number(ComponentMajor, func(v *Version, n string) { v.Major = n })
 }
 
 func minor() consumer {
-	return number(func(v *Version, n string) { v.Minor = n })
+	return number(ComponentMinor, func(v *Version, n string) { v.Minor = n })
 }
 
 func patch() consumer {
-	return number(func(v *Version, n string) { v.Patch = n })
+	return number(ComponentPatch, func(v *Version, n string) { v.Patch = n })
 }
 
 func sequence(cs ...consumer) consumer {
@@ -474,11 +693,12 @@ func optional(cs consumer, c ...consumer) consumer {
 		pos += (len(stream) - len(remain))
 
 		for _, consumer := range c {
+			oldRemainLen := len(remain)
 			remain, err = consumer(pos, remain, v)
 			if err != nil {
 				return stream, err
 			}
-			pos += (len(stream) - len(remain))
+			pos += (oldRemainLen - len(remain))
 		}
 		return remain, err
 	}
@@ -502,10 +722,14 @@ func repeat(c ...consumer) consumer {
 	}
 }
 
+func isIdentifierChar(s rune) bool {
+	return (s >= '0' && s <= '9') || (s >= 'a' && s <= 'z') || (s >= 'A' && s <= 'Z') || s == '-'
+}
+
 func prerelease() consumer {
 	return func(pos int, stream string, v *Version) (remain string, err error) {
 		if stream == "" {
-			return "", positionErr(pos, "unexpected end of stream in prerelease")
+			return "", positionErr(pos, ComponentPrerelease, ErrUnexpectedEnd, "unexpected end of stream in prerelease")
 		}
 		remain = stream
 		numberFlag := true
@@ -513,15 +737,15 @@ func prerelease() consumer {
 		for i, s := range stream {
 			if s == '.' || s == '+' {
 				if i == 0 {
-					return stream, positionErr(pos+i, "unexpected empty prerelease")
+					return stream, positionErr(pos+i, ComponentPrerelease, ErrEmptyIdentifier, "unexpected empty prerelease")
 				}
 				token = stream[:i]
 				v.Prerelease = append(v.Prerelease, token)
 				remain = stream[i:]
 				break
 			}
-			if (s < '0' || s > '9') && (s < 'a' || s > 'z') && (s < 'A' || s > 'Z') && s != '-' {
-				return stream, positionErr(pos+i-1, "invalid character in prerelease")
+			if !isIdentifierChar(s) {
+				return stream, positionErr(pos+i, ComponentPrerelease, ErrInvalidCharacter, "invalid character in prerelease")
 			}
 			if s < '0' || s > '9' {
 				numberFlag = false
@@ -534,7 +758,7 @@ func prerelease() consumer {
 		}
 		if token != "" && numberFlag {
 			if len(token) > 1 && token[0] == '0' {
-				return stream, positionErr(pos, "unexpected leading zero")
+				return stream, positionErr(pos, ComponentPrerelease, ErrLeadingZero, "unexpected leading zero")
 			}
 		}
 		return remain, err
@@ -544,18 +768,18 @@ func prerelease() consumer {
 func buildmetadata() consumer {
 	return func(pos int, stream string, v *Version) (remain string, err error) {
 		if stream == "" {
-			return "", positionErr(pos, "unexpected end of stream in buildmetadata")
+			return "", positionErr(pos, ComponentBuildmetadata, ErrUnexpectedEnd, "unexpected end of stream in buildmetadata")
 		}
 		for i, s := range stream {
 			if s == '.' || s == '+' {
 				if i == 0 {
-					return stream, positionErr(pos+i, "unexpected empty prerelease")
+					return stream, positionErr(pos+i, ComponentBuildmetadata, ErrEmptyIdentifier, "unexpected empty buildmetadata")
 				}
 				v.Buildmetadata = append(v.Buildmetadata, stream[:i])
 				return stream[i:], nil
 			}
-			if (s < '0' || s > '9') && (s < 'a' || s > 'z') && (s < 'A' || s > 'Z') && s != '-' {
-				return stream, positionErr(pos+i, "invalid character in buildmetadata")
+			if !isIdentifierChar(s) {
+				return stream, positionErr(pos+i, ComponentBuildmetadata, ErrInvalidCharacter, "invalid character in buildmetadata")
 			}
 		}
 		v.Buildmetadata = append(v.Buildmetadata, stream)
@@ -566,7 +790,7 @@ func buildmetadata() consumer {
 func excess() consumer {
 	return func(pos int, stream string, v *Version) (remain string, err error) {
 		if stream != "" {
-			return stream, positionErr(pos, "unexpected extra data")
+			return stream, positionErr(pos, "", ErrExtraData, "unexpected extra data")
 		}
 		return "", nil
 	}
@@ -1,6 +1,7 @@
 package semver_test
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -265,6 +266,27 @@ func TestSortSemver(t *testing.T) {
 			[]string{"3.6.1", "3.6.2", "3.6.10"},
 			false,
 		},
+		// numeric prerelease identifiers have no size limit
+		{"sorting huge numeric prerelease identifiers",
+			[]string{
+				"1.0.0-2",
+				"1.0.0-9223372036854775807",
+				"1.0.0-9223372036854775808",
+				"1.0.0-99999999999999999999",
+				"1.0.0-100000000000000000000",
+				"1.0.0-100000000000000000000.1",
+				"1.0.0-100000000000000000000.99999999999999999999",
+				"1.0.0-100000000000000000000.a",
+				"1.0.0-0a",
+				"1.0.0-99999999999999999999a",
+				"1.0.0-a",
+			},
+			false,
+		},
+		{"sorting identifiers following equal numeric ones",
+			[]string{"1.0.0-1.a", "1.0.0-1.b", "1.0.0-1.b.0", "1.0.0-2"},
+			false,
+		},
 		// build metadata should be ignored
 		{"sorting with build metadata - equals",
 			[]string{"1.0.0+B", "1.0.0+A", "1.0.0+C", "1.0.0"},
@@ -307,6 +329,91 @@ func TestSortSemver(t *testing.T) {
 	}
 }
 
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		want   int
+	}{
+		{"1.0.0", "2.0.0", -1},
+		{"2.0.0", "1.0.0", 1},
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0+a", "1.0.0+b", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-alpha.beta", "1.0.0-alpha.1", 1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", tt.v1, tt.v2), func(t *testing.T) {
+			v1 := semver.MustParse(tt.v1)
+			v2 := semver.MustParse(tt.v2)
+
+			if got := semver.Compare(&v1, &v2); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+			if got := v2.Compare(&v1); got != -tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.v2, tt.v1, got, -tt.want)
+			}
+			checks := []struct {
+				name string
+				got  bool
+				want bool
+			}{
+				{"Less", v1.Less(&v2), tt.want < 0},
+				{"LessOrEqual", v1.LessOrEqual(&v2), tt.want <= 0},
+				{"Greater", v1.Greater(&v2), tt.want > 0},
+				{"GreaterOrEqual", v1.GreaterOrEqual(&v2), tt.want >= 0},
+				{"Equal", v1.Equal(&v2), tt.want == 0},
+				{"Less (function)", semver.Less(&v1, &v2), tt.want < 0},
+				{"LessOrEqual (function)", semver.LessOrEqual(&v1, &v2), tt.want <= 0},
+				{"Greater (function)", semver.Greater(&v1, &v2), tt.want > 0},
+				{"GreaterOrEqual (function)", semver.GreaterOrEqual(&v1, &v2), tt.want >= 0},
+				{"Equal (function)", semver.Equal(&v1, &v2), tt.want == 0},
+			}
+			for _, c := range checks {
+				if c.got != c.want {
+					t.Errorf("%s(%s, %s) = %v, want %v", c.name, tt.v1, tt.v2, c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareWithBuild(t *testing.T) {
+	sorted := []string{
+		"1.0.0-rc.1+z",
+		"1.0.0",
+		"1.0.0+1",
+		"1.0.0+01",
+		"1.0.0+2",
+		"1.0.0+10",
+		"1.0.0+a",
+		"1.0.0+a.1",
+		"1.0.0+a.b",
+		"1.0.0+b",
+		"1.0.1",
+	}
+	for i := range sorted {
+		for j := range sorted {
+			v1 := semver.MustParse(sorted[i])
+			v2 := semver.MustParse(sorted[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := semver.CompareWithBuild(&v1, &v2); got != want {
+				t.Errorf("CompareWithBuild(%s, %s) = %d, want %d", sorted[i], sorted[j], got, want)
+			}
+			if got := semver.LessWithBuild(&v1, &v2); got != (want < 0) {
+				t.Errorf("LessWithBuild(%s, %s) = %v, want %v", sorted[i], sorted[j], got, want < 0)
+			}
+		}
+	}
+}
+
 func less(v1, v2 string) bool {
 	s1 := semver.MustParse(v1)
 	s2 := semver.MustParse(v2)
@@ -401,6 +508,22 @@ func ExampleLess() {
 	// 9. 1.0.0+3rd
 }
 
+func ExampleCompare() {
+	versions := []semver.Version{
+		semver.MustParse("1.0.0"),
+		semver.MustParse("1.2.0"),
+		semver.MustParse("2.0.0-rc.1"),
+		semver.MustParse("2.0.0"),
+	}
+	target := semver.MustParse("2.0.0-rc.1")
+	i := sort.Search(len(versions), func(i int) bool {
+		return semver.Compare(&versions[i], &target) >= 0
+	})
+	fmt.Printf("%d: %s", i, versions[i].String())
+	// Output:
+	// 2: 2.0.0-rc.1
+}
+
 func TestVersion_Bump(t *testing.T) {
 	type opts = []semver.BumpOption
 
@@ -485,6 +608,131 @@ func TestVersion_Bump(t *testing.T) {
 	}
 }
 
+func TestVersion_NextPrerelease(t *testing.T) {
+	data := []struct {
+		version string
+		option  semver.BumpOption
+		want    string
+		err     error
+	}{
+		{"1.0.0-rc.1", semver.NextPrerelease(), "1.0.0-rc.2", nil},
+		{"1.0.0-alpha", semver.NextPrerelease(), "", semver.ErrNoNumericPrerelease},
+		{"1.0.0-alpha.beta", semver.NextPrerelease(), "", semver.ErrNoNumericPrerelease},
+		{"1.0.0", semver.NextPrerelease(), "", semver.ErrNoPrerelease},
+		{"1.0.0-alpha", semver.NextPrerelease(semver.AppendCounter(1)), "1.0.0-alpha.1", nil},
+		{"1.0.0-alpha", semver.NextPrerelease(semver.AppendCounter(0)), "1.0.0-alpha.0", nil},
+		{"1.0.0-alpha.1", semver.NextPrerelease(semver.AppendCounter(0)), "1.0.0-alpha.2", nil},
+		{"1.0.0-rc.1", semver.EnsureGreater(semver.NextPrerelease()), "1.0.0-rc.2", nil},
+		{"1.0.0-rc.1", semver.EnsureGreater(semver.NextRelease()), "1.0.0", nil},
+		{"1.0.0-rc.1", semver.EnsureGreater(semver.NextRelease(), semver.Prerelease("alpha")), "", semver.ErrNotGreater},
+		{"1.0.0-rc.1", semver.EnsureGreater(), "", semver.ErrNotGreater},
+		{"1.0.0-alpha", semver.EnsureGreater(semver.NextPrerelease()), "", semver.ErrNoNumericPrerelease},
+	}
+	for _, tt := range data {
+		t.Run(tt.version+"->"+tt.want, func(t *testing.T) {
+			v := semver.MustParse(tt.version)
+			got, err := v.Bump(tt.option)
+			if v.String() != tt.version {
+				t.Errorf("original version was modified: %s", v.String())
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Bump() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() returned unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Bump() = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_BumpEnsureGreater(t *testing.T) {
+	data := []struct {
+		version string
+		options []semver.BumpOption
+		want    string
+		err     error
+	}{
+		{"1.2.3", []semver.BumpOption{semver.NextPatch(), semver.EnsureGreater()}, "1.2.4", nil},
+		{"1.2.3", []semver.BumpOption{semver.EnsureGreater(), semver.NextPatch()}, "1.2.4", nil},
+		{"1.2.3", []semver.BumpOption{semver.NextPatch(), semver.EnsureGreater(semver.Prerelease("rc"))}, "1.2.4-rc", nil},
+		{"1.2.3", []semver.BumpOption{semver.EnsureGreater(semver.EnsureGreater(), semver.NextMinor())}, "1.3.0", nil},
+		{"1.2.3", []semver.BumpOption{semver.BuildMetadata("b"), semver.EnsureGreater()}, "", semver.ErrNotGreater},
+		{"1.2.3", []semver.BumpOption{semver.Prerelease("rc"), semver.EnsureGreater()}, "", semver.ErrNotGreater},
+	}
+	for _, tt := range data {
+		t.Run(tt.version+"->"+tt.want, func(t *testing.T) {
+			v := semver.MustParse(tt.version)
+			got, err := v.Bump(tt.options...)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Bump() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bump() returned unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Bump() = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_BumpNpmPre(t *testing.T) {
+	data := []struct {
+		version string
+		option  semver.BumpOption
+		want    string
+	}{
+		{"1.2.3", semver.PreMajor("rc"), "2.0.0-rc.0"},
+		{"1.2.3-rc.4", semver.PreMajor("rc"), "2.0.0-rc.0"},
+		{"1.2.3", semver.PreMajor(""), "2.0.0-0"},
+		{"1.2.3", semver.PreMinor("rc"), "1.3.0-rc.0"},
+		{"1.2.3-beta.1", semver.PreMinor("alpha"), "1.3.0-alpha.0"},
+		{"1.2.3", semver.PrePatch("rc"), "1.2.4-rc.0"},
+		{"1.2.3-rc.0", semver.PrePatch("rc"), "1.2.4-rc.0"},
+		{"1.2.3", semver.PreRelease("rc"), "1.2.4-rc.0"},
+		{"1.2.3", semver.PreRelease(""), "1.2.4-0"},
+		{"1.2.3-rc.0", semver.PreRelease("rc"), "1.2.3-rc.1"},
+		{"1.2.3-rc.0", semver.PreRelease(""), "1.2.3-rc.1"},
+		{"1.2.3-rc", semver.PreRelease("rc"), "1.2.3-rc.0"},
+		{"1.2.3-rc", semver.PreRelease(""), "1.2.3-rc.0"},
+		{"1.2.3-rc.1.x", semver.PreRelease("rc"), "1.2.3-rc.2.x"},
+		{"1.2.3-alpha.3", semver.PreRelease("rc"), "1.2.3-rc.0"},
+		{"1.2.3-0", semver.PreRelease(""), "1.2.3-1"},
+		{"1.2.3-99999999999999999999", semver.PreRelease(""), "1.2.3-100000000000000000000"},
+	}
+	for _, tt := range data {
+		t.Run(tt.version+"->"+tt.want, func(t *testing.T) {
+			v := semver.MustParse(tt.version)
+			got, err := v.Bump(tt.option)
+			if err != nil {
+				t.Fatalf("Bump() returned unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Bump() = %s, want %s", got.String(), tt.want)
+			}
+			if v.String() != tt.version {
+				t.Errorf("original version was modified: %s", v.String())
+			}
+		})
+	}
+
+	v := semver.MustParse("1.2.3")
+	for _, id := range []string{"r.c", "01", "r+c", "rc!"} {
+		if _, err := v.Bump(semver.PreMajor(id)); err == nil {
+			t.Errorf("expected error bumping with invalid prerelease id %q", id)
+		}
+	}
+}
+
 func TestVersion_Valid(t *testing.T) {
 	tests := []struct {
 		name    string
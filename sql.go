@@ -0,0 +1,134 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scan implements sql.Scanner interface. Value read from database must be a string or byte slice holding
+// valid version; use NullVersion for columns that may be NULL.
+func (semver *Version) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return semver.UnmarshalText([]byte(src))
+	case []byte:
+		return semver.UnmarshalText(src)
+	case nil:
+		return errors.New("can not scan NULL into Version; use NullVersion instead")
+	}
+	return fmt.Errorf("can not scan %T into Version", src)
+}
+
+// Value implements driver.Valuer interface. Version is stored as its string form.
+func (semver Version) Value() (driver.Value, error) {
+	text, err := semver.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner interface; value is parsed with ParseModuleVersion so it must have `v` prefix
+func (m *ModuleVersion) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return m.UnmarshalText([]byte(src))
+	case []byte:
+		return m.UnmarshalText(src)
+	case nil:
+		return errors.New("can not scan NULL into ModuleVersion")
+	}
+	return fmt.Errorf("can not scan %T into ModuleVersion", src)
+}
+
+// Value implements driver.Valuer interface. Module version is stored with `v` prefix.
+func (m ModuleVersion) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// NullVersion represents a Version that may be NULL. It works like sql.NullString: Valid is false if value is NULL.
+type NullVersion struct {
+	Version Version
+	Valid   bool
+}
+
+// Scan implements sql.Scanner interface
+func (n *NullVersion) Scan(src interface{}) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	if err := n.Version.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
+}
+
+// ErrOverflow is returned when number is too large to fit into requested representation
+var ErrOverflow = errors.New("number too large")
+
+// sortKeyWidth is number of digits every number is padded to; it fits any uint64 value
+const sortKeyWidth = 20
+
+// SortKey returns string which, compared byte by byte, orders versions by their precedence. It is meant to be
+// stored next to the version in a database column so `ORDER BY` on it gives semantic order rather than lexical
+// one. Column should use binary collation (e.g. `COLLATE "C"` in Postgres) as the key relies on ASCII order.
+//
+// Core numbers and numeric prerelease identifiers are zero-padded to 20 digits; ErrOverflow is returned if any
+// of them is longer. Build metadata does not affect precedence and is not part of the key.
+func (semver *Version) SortKey() (string, error) {
+	if !semver.Valid() {
+		return "", ErrCorruptedVersion
+	}
+	var b strings.Builder
+	for i, n := range []string{semver.Major, semver.Minor, semver.Patch} {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		if err := writePadded(&b, n); err != nil {
+			return "", err
+		}
+	}
+	if len(semver.Prerelease) == 0 {
+		// release is greater than any prerelease; '~' sorts after '-'
+		b.WriteByte('~')
+		return b.String(), nil
+	}
+	b.WriteByte('-')
+	for i, id := range semver.Prerelease {
+		if i > 0 {
+			// '!' sorts before any identifier character so shorter list of identifiers comes first
+			b.WriteByte('!')
+		}
+		if isNum(id) {
+			b.WriteByte('1')
+			if err := writePadded(&b, id); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte('2')
+		b.WriteString(id)
+	}
+	return b.String(), nil
+}
+
+func writePadded(b *strings.Builder, n string) error {
+	if len(n) > sortKeyWidth {
+		return fmt.Errorf("%w: %s has more than %d digits", ErrOverflow, n, sortKeyWidth)
+	}
+	b.WriteString(strings.Repeat("0", sortKeyWidth-len(n)))
+	b.WriteString(n)
+	return nil
+}
@@ -0,0 +1,173 @@
+package semver_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestVersion_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{"string", "1.2.3-rc.1+build", "1.2.3-rc.1+build", false},
+		{"bytes", []byte("1.2.3"), "1.2.3", false},
+		{"invalid string", "1.2", "", true},
+		{"null", nil, "", true},
+		{"integer", int64(1), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v semver.Version
+			err := v.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && v.String() != tt.want {
+				t.Errorf("Scan() = %s, want %s", v.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Value(t *testing.T) {
+	value, err := semver.MustParse("1.2.3-rc.1+build").Value()
+	if err != nil {
+		t.Fatalf("Value() returned unexpected error: %v", err)
+	}
+	if value != "1.2.3-rc.1+build" {
+		t.Errorf("Value() = %v, want 1.2.3-rc.1+build", value)
+	}
+
+	if _, err := (semver.Version{Major: "01", Minor: "2", Patch: "3"}).Value(); err == nil {
+		t.Errorf("expected error for invalid version")
+	}
+}
+
+func TestNullVersion(t *testing.T) {
+	var n semver.NullVersion
+	if err := n.Scan("1.2.3"); err != nil {
+		t.Fatalf("Scan() returned unexpected error: %v", err)
+	}
+	if !n.Valid || n.Version.String() != "1.2.3" {
+		t.Errorf("Scan() = %+v, want valid 1.2.3", n)
+	}
+	if value, err := n.Value(); err != nil || value != "1.2.3" {
+		t.Errorf("Value() = %v, %v, want 1.2.3", value, err)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan() returned unexpected error: %v", err)
+	}
+	if n.Valid {
+		t.Errorf("Scan(nil) should result with invalid NullVersion, got %+v", n)
+	}
+	if value, err := n.Value(); err != nil || value != nil {
+		t.Errorf("Value() = %v, %v, want nil", value, err)
+	}
+
+	if err := n.Scan("invalid"); err == nil {
+		t.Errorf("expected error scanning invalid version")
+	}
+}
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := []string{
+		"0.0.0-0",
+		"0.0.0",
+		"1.0.0-1",
+		"1.0.0-1.a",
+		"1.0.0-1.b",
+		"1.0.0-1.b.0",
+		"1.0.0-2",
+		"1.0.0-10",
+		"1.0.0-99999999999999999999",
+		"1.0.0-0a",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-alpha-x",
+		"1.0.0-alphax",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+		"10.0.0",
+		"18446744073709551616.0.0",
+	}
+	keys := make([]string, 0, len(versions))
+	for _, s := range versions {
+		v := semver.MustParse(s)
+		key, err := v.SortKey()
+		if err != nil {
+			t.Fatalf("SortKey(%s) returned unexpected error: %v", s, err)
+		}
+		keys = append(keys, key)
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Errorf("keys are not sorted in order of versions precedence")
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Errorf("key of %s should be lower than key of %s", versions[i-1], versions[i])
+		}
+	}
+
+	v1, v2 := semver.MustParse("1.2.3-rc.1+a"), semver.MustParse("1.2.3-rc.1+b")
+	k1, _ := v1.SortKey()
+	k2, _ := v2.SortKey()
+	if k1 != k2 {
+		t.Errorf("build metadata should not affect key: %s != %s", k1, k2)
+	}
+}
+
+func TestVersion_SortKeyOverflow(t *testing.T) {
+	for _, s := range []string{"100000000000000000000.0.0", "1.0.0-100000000000000000000"} {
+		v := semver.MustParse(s)
+		if _, err := v.SortKey(); !errors.Is(err, semver.ErrOverflow) {
+			t.Errorf("SortKey(%s) error = %v, want ErrOverflow", s, err)
+		}
+	}
+}
+
+func TestModuleVersion_SQL(t *testing.T) {
+	for _, s := range []string{"v1.2.3", "v2.0.0+incompatible", "v0.0.0-20191109021931-daa7c04131f5"} {
+		t.Run(s, func(t *testing.T) {
+			m := semver.MustParseModuleVersion(s)
+			value, err := m.Value()
+			if err != nil {
+				t.Fatalf("Value() returned unexpected error: %v", err)
+			}
+			if value != s {
+				t.Errorf("Value() = %v, want %s", value, s)
+			}
+			var scanned semver.ModuleVersion
+			if err := scanned.Scan(value); err != nil {
+				t.Fatalf("Scan() returned unexpected error: %v", err)
+			}
+			if scanned.String() != s {
+				t.Errorf("Scan() = %s, want %s", scanned.String(), s)
+			}
+			if err := scanned.Scan([]byte(s)); err != nil || scanned.String() != s {
+				t.Errorf("Scan() of bytes = %s, %v, want %s", scanned.String(), err, s)
+			}
+		})
+	}
+
+	var m semver.ModuleVersion
+	for _, src := range []interface{}{"1.2.3", "v2.0.0+build", nil, 12} {
+		if err := m.Scan(src); err == nil {
+			t.Errorf("expected error scanning %v", src)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Correction is a fix applied by ParseTolerant to turn input into a valid version
+type Correction int
+
+const (
+	// StrippedPrefix means `v` or `V` prefix was removed: `v1.2.3` -> `1.2.3`
+	StrippedPrefix Correction = iota + 1
+	// ExtractedSubstring means text surrounding version was removed: `release-1.2.3` -> `1.2.3`
+	ExtractedSubstring
+	// FilledMissing means missing minor or patch number was set to zero: `1.2` -> `1.2.0`
+	FilledMissing
+	// DroppedLeadingZeros means leading zeros were removed from numbers: `1.02.3` -> `1.2.3`
+	DroppedLeadingZeros
+	// MovedToBuild means numbers following patch were moved to build metadata: `1.2.3.4` -> `1.2.3+4`
+	MovedToBuild
+)
+
+// String returns short description of the correction
+func (c Correction) String() string {
+	switch c {
+	case StrippedPrefix:
+		return "stripped prefix"
+	case ExtractedSubstring:
+		return "extracted substring"
+	case FilledMissing:
+		return "filled missing numbers"
+	case DroppedLeadingZeros:
+		return "dropped leading zeros"
+	case MovedToBuild:
+		return "moved extra numbers to build metadata"
+	}
+	return fmt.Sprintf("unknown correction %d", int(c))
+}
+
+// TolerantOption is function option that enables one of corrections made by ParseTolerant
+type TolerantOption func(*tolerant)
+
+type tolerant struct {
+	stripPrefix      bool
+	extract          bool
+	fillMissing      bool
+	dropLeadingZeros bool
+	extraToBuild     bool
+
+	corrections []Correction
+}
+
+func (t *tolerant) correct(c Correction) {
+	for _, applied := range t.corrections {
+		if applied == c {
+			return
+		}
+	}
+	t.corrections = append(t.corrections, c)
+}
+
+// StripPrefix allows version to be prefixed with `v` or `V` as it is common in tags: `v1.2.3`
+func StripPrefix() TolerantOption {
+	return func(t *tolerant) {
+		t.stripPrefix = true
+	}
+}
+
+// ExtractFirst makes ParseTolerant look for the first version-looking substring and ignore everything around it:
+// `release-1.2.3`, `app 1.2.3 (stable)`
+func ExtractFirst() TolerantOption {
+	return func(t *tolerant) {
+		t.extract = true
+	}
+}
+
+// FillMissing allows minor and patch numbers to be omitted; they are set to zero: `1` -> `1.0.0`, `1.2` -> `1.2.0`
+func FillMissing() TolerantOption {
+	return func(t *tolerant) {
+		t.fillMissing = true
+	}
+}
+
+// DropLeadingZeros removes leading zeros from core numbers and numeric prerelease identifiers: `1.02.3` -> `1.2.3`
+func DropLeadingZeros() TolerantOption {
+	return func(t *tolerant) {
+		t.dropLeadingZeros = true
+	}
+}
+
+// ExtraToBuild turns fourth and further numbers into build metadata identifiers: `1.2.3.4` -> `1.2.3+4`
+func ExtraToBuild() TolerantOption {
+	return func(t *tolerant) {
+		t.extraToBuild = true
+	}
+}
+
+// ParseTolerant works like Parse but accepts strings that are not valid versions yet may be corrected with
+// enabled options. Result is always strict and valid version, returned along with list of corrections that
+// were needed to get it. If string can not be fixed error is returned.
+func ParseTolerant(s string, options ...TolerantOption) (Version, []Correction, error) {
+	t := tolerant{}
+	for _, o := range options {
+		o(&t)
+	}
+
+	corrected := t.fix(strings.TrimSpace(s))
+
+	v, err := Parse(corrected)
+	if err != nil {
+		return Version{}, nil, err
+	}
+	return v, t.corrections, nil
+}
+
+// Coerce is ParseTolerant with all corrections enabled
+func Coerce(s string) (Version, []Correction, error) {
+	return ParseTolerant(s, StripPrefix(), ExtractFirst(), FillMissing(), DropLeadingZeros(), ExtraToBuild())
+}
+
+func (t *tolerant) fix(s string) string {
+	if t.stripPrefix && len(s) > 1 && (s[0] == 'v' || s[0] == 'V') && isDigit(s[1]) {
+		s = s[1:]
+		t.correct(StrippedPrefix)
+	}
+	if t.extract {
+		s = t.extractFirst(s)
+	}
+
+	core, qualifier := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, qualifier = s[:i], s[i:]
+	}
+	prerelease, build := qualifier, ""
+	if i := strings.Index(qualifier, "+"); i >= 0 {
+		prerelease, build = qualifier[:i], qualifier[i:]
+	}
+
+	const coreNumbers = 3
+	numbers := strings.Split(core, ".")
+	if t.extraToBuild && len(numbers) > coreNumbers {
+		extra := strings.Join(numbers[coreNumbers:], ".")
+		if build == "" {
+			build = "+" + extra
+		} else {
+			build = "+" + extra + "." + build[1:]
+		}
+		numbers = numbers[:coreNumbers]
+		t.correct(MovedToBuild)
+	}
+	if t.fillMissing && len(numbers) < coreNumbers && numbers[len(numbers)-1] != "" {
+		for len(numbers) < coreNumbers {
+			numbers = append(numbers, "0")
+		}
+		t.correct(FilledMissing)
+	}
+	if t.dropLeadingZeros {
+		numbers = t.trimZeros(numbers)
+		if len(prerelease) > 1 {
+			prerelease = "-" + strings.Join(t.trimZeros(strings.Split(prerelease[1:], ".")), ".")
+		}
+	}
+	return strings.Join(numbers, ".") + prerelease + build
+}
+
+// extractFirst returns the first substring that starts with a digit and consists of characters allowed in versions.
+// Substrings starting with at least two dot separated numbers are preferred so that `x64-1.2.3` gives `1.2.3`;
+// bare number is taken only if there is nothing better.
+func (t *tolerant) extractFirst(s string) string {
+	start := versionLikeStart(s)
+	if start < 0 {
+		start = strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	}
+	if start < 0 {
+		return s
+	}
+	end := start
+	for end < len(s) && (isIdentifierChar(rune(s[end])) || s[end] == '.' || s[end] == '+') {
+		end++
+	}
+	// dots and signs at the end are punctuation rather than part of version: `version 1.2.3.`
+	for end > start && strings.ContainsRune(".+-", rune(s[end-1])) {
+		end--
+	}
+	if start > 0 || end < len(s) {
+		t.correct(ExtractedSubstring)
+	}
+	return s[start:end]
+}
+
+// versionLikeStart returns index of the first number followed by a dot and another number: `N.N`, or -1
+func versionLikeStart(s string) int {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) || i > 0 && isDigit(s[i-1]) {
+			continue
+		}
+		end := i
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end+1 < len(s) && s[end] == '.' && isDigit(s[end+1]) {
+			return i
+		}
+		i = end
+	}
+	return -1
+}
+
+func (t *tolerant) trimZeros(ids []string) []string {
+	trimmed := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if len(id) > 1 && id[0] == '0' && isNum(id) {
+			id = strings.TrimLeft(id, "0")
+			if id == "" {
+				id = "0"
+			}
+			t.correct(DroppedLeadingZeros)
+		}
+		trimmed = append(trimmed, id)
+	}
+	return trimmed
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
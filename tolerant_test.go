@@ -0,0 +1,88 @@
+package semver_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/adamwasila/go-semver"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		input       string
+		want        string
+		corrections []semver.Correction
+	}{
+		{"1.2.3", "1.2.3", nil},
+		{"v1.2", "1.2.0", []semver.Correction{semver.StrippedPrefix, semver.FilledMissing}},
+		{"V1.2.3", "1.2.3", []semver.Correction{semver.StrippedPrefix}},
+		{"1", "1.0.0", []semver.Correction{semver.FilledMissing}},
+		{"1.2.3.4", "1.2.3+4", []semver.Correction{semver.MovedToBuild}},
+		{"1.2.3.4-rc.1+abc", "1.2.3-rc.1+4.abc", []semver.Correction{semver.MovedToBuild}},
+		{"release-1.2.3", "1.2.3", []semver.Correction{semver.ExtractedSubstring}},
+		{"app version 2.0.1-beta.2, built today", "2.0.1-beta.2", []semver.Correction{semver.ExtractedSubstring}},
+		{"Version 1.2.3.", "1.2.3", []semver.Correction{semver.ExtractedSubstring}},
+		{"x64-1.2.3", "1.2.3", []semver.Correction{semver.ExtractedSubstring}},
+		{"python3 1.2.3", "1.2.3", []semver.Correction{semver.ExtractedSubstring}},
+		{"build 42", "42.0.0", []semver.Correction{semver.ExtractedSubstring, semver.FilledMissing}},
+		{"1.02.3", "1.2.3", []semver.Correction{semver.DroppedLeadingZeros}},
+		{"01.00.003-rc.01", "1.0.3-rc.1", []semver.Correction{semver.DroppedLeadingZeros}},
+		{"  v01.2  ", "1.2.0", []semver.Correction{semver.StrippedPrefix, semver.FilledMissing, semver.DroppedLeadingZeros}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, corrections, err := semver.Coerce(tt.input)
+			if err != nil {
+				t.Fatalf("Coerce(%s) returned unexpected error: %v", tt.input, err)
+			}
+			if v.String() != tt.want {
+				t.Errorf("Coerce(%s) = %s, want %s", tt.input, v.String(), tt.want)
+			}
+			if !v.Valid() {
+				t.Errorf("Coerce(%s) = %s is not valid", tt.input, v.String())
+			}
+			if !reflect.DeepEqual(corrections, tt.corrections) {
+				t.Errorf("Coerce(%s) corrections = %v, want %v", tt.input, corrections, tt.corrections)
+			}
+		})
+	}
+}
+
+func TestParseTolerantOptions(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []semver.TolerantOption
+		want    string
+		wantErr bool
+	}{
+		{"v1.2.3", nil, "", true},
+		{"v1.2.3", []semver.TolerantOption{semver.StripPrefix()}, "1.2.3", false},
+		{"v1.2", []semver.TolerantOption{semver.StripPrefix()}, "", true},
+		{"1.2", []semver.TolerantOption{semver.FillMissing()}, "1.2.0", false},
+		{"1.2.", []semver.TolerantOption{semver.FillMissing()}, "", true},
+		{"1.02.3", []semver.TolerantOption{semver.FillMissing()}, "", true},
+		{"1.2.3.4", []semver.TolerantOption{semver.DropLeadingZeros()}, "", true},
+		{"release-1.2.3", []semver.TolerantOption{semver.StripPrefix()}, "", true},
+		{"release-1.2.3", []semver.TolerantOption{semver.ExtractFirst()}, "1.2.3", false},
+		{"no version here", []semver.TolerantOption{semver.ExtractFirst()}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s with %d options", tt.input, len(tt.options)), func(t *testing.T) {
+			v, _, err := semver.ParseTolerant(tt.input, tt.options...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTolerant(%s) returned error: %v, expected error: %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && v.String() != tt.want {
+				t.Errorf("ParseTolerant(%s) = %s, want %s", tt.input, v.String(), tt.want)
+			}
+		})
+	}
+}
+
+func ExampleCoerce() {
+	v, corrections, _ := semver.Coerce("release-v1.02")
+	fmt.Println(v.String(), corrections)
+	// Output:
+	// 1.2.0 [extracted substring filled missing numbers dropped leading zeros]
+}